@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+
+provider "azapi" {
+}
+
+data "azapi_data_plane_resource" "example" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  parent_id = "myappconf.azconfig.io"
+  name      = "mykey"
+  response_export_values = {
+    "value" = "value"
+  }
+}
+
+output "value" {
+  value = data.azapi_data_plane_resource.example.output.value
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+
+provider "azapi" {
+}
+
+data "azapi_data_plane_resource_action" "example" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "myappconf.azconfig.io/kv/mykey"
+  method      = "GET"
+  response_export_values = {
+    content_type = "content_type"
+  }
+  sensitive_response_export_values = {
+    value = "value"
+  }
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+
+provider "azapi" {
+}
+
+data "azapi_data_plane_resource_list" "listKeyValues" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  parent_id = "myappconf.azconfig.io"
+  response_export_values = {
+    "keys" = "value[].key"
+  }
+}
+
+data "azapi_data_plane_resource_list" "listRelationships" {
+  type                   = "Microsoft.DigitalTwins/digitalTwinsInstances/digitalTwins/relationships@2023-10-31"
+  parent_id              = "mydt.api.wus2.digitaltwins.azure.net/digitaltwins/mytwin"
+  response_export_values = ["*"]
+}
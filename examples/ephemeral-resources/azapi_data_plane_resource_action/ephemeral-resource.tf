@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+
+provider "azapi" {
+}
+
+// the secret value is only available during the run and is never persisted in the state
+ephemeral "azapi_data_plane_resource_action" "secret" {
+  type        = "Microsoft.KeyVault/vaults/secrets@7.4"
+  resource_id = "mykeyvault.vault.azure.net/secrets/mysecret"
+  method      = "GET"
+  response_export_values = {
+    value = "value"
+  }
+}
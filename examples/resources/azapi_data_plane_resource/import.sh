@@ -0,0 +1,5 @@
+# Azure data plane resource can be imported using the resource id with api-version as a query parameter, e.g.
+terraform import azapi_data_plane_resource.example myappconf.azconfig.io/kv/mykey?api-version=1.0
+
+# It also supports specifying the resource type and the resource id separated by `|`, it's useful when the resource type can't be inferred from the resource id, e.g.
+terraform import azapi_data_plane_resource.example "Microsoft.AppConfiguration/configurationStores/keyValues@1.0|myappconf.azconfig.io/kv/mykey"
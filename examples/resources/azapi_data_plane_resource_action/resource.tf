@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}
+
+provider "azapi" {
+}
+
+// lock the key-value when the resource is created
+resource "azapi_data_plane_resource_action" "lock" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "myappconf.azconfig.io/locks/mykey"
+  method      = "PUT"
+  response_export_values = {
+    locked = "locked"
+  }
+}
+
+// unlock the key-value when the resource is destroyed
+resource "azapi_data_plane_resource_action" "unlock" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "myappconf.azconfig.io/locks/mykey"
+  method      = "DELETE"
+  when        = "destroy"
+}
+
+// rotate a key vault key
+resource "azapi_data_plane_resource_action" "rotate" {
+  type        = "Microsoft.KeyVault/vaults/keys@7.4"
+  resource_id = "mykeyvault.vault.azure.net/keys/mykey"
+  action      = "rotate"
+  response_export_values = {
+    kid = "key.kid"
+  }
+}
@@ -0,0 +1,69 @@
+package azure
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OutdatedApiVersionThreshold is the duration an api-version can be behind the latest api-version before it's considered outdated
+const OutdatedApiVersionThreshold = 2 * 365 * 24 * time.Hour
+
+// ApiVersionWarnings returns the warnings about the api-version of the resource type, it checks whether the api-version is a preview version
+// which has a stable version released later, and whether the api-version is years behind the latest api-version.
+func ApiVersionWarnings(resourceType string, apiVersion string) []string {
+	return apiVersionWarnings(apiVersion, GetApiVersions(resourceType))
+}
+
+func apiVersionWarnings(apiVersion string, apiVersions []string) []string {
+	date, ok := apiVersionDate(apiVersion)
+	if !ok {
+		return nil
+	}
+
+	var latest, latestStable string
+	var latestDate, latestStableDate time.Time
+	for _, v := range apiVersions {
+		d, ok := apiVersionDate(v)
+		if !ok {
+			continue
+		}
+		if latest == "" || !d.Before(latestDate) {
+			latest, latestDate = v, d
+		}
+		if !IsPreviewApiVersion(v) && (latestStable == "" || !d.Before(latestStableDate)) {
+			latestStable, latestStableDate = v, d
+		}
+	}
+
+	warnings := make([]string, 0)
+	if IsPreviewApiVersion(apiVersion) && latestStable != "" && !latestStableDate.Before(date) {
+		warnings = append(warnings, fmt.Sprintf("The api-version %s is a preview version, and the stable api-version %s is available.", apiVersion, latestStable))
+	}
+
+	// compare with the latest stable api-version, the preview api-versions are excluded unless there's no stable api-version
+	if latestStable != "" {
+		latest, latestDate = latestStable, latestStableDate
+	}
+	if latest != "" && latestDate.Sub(date) > OutdatedApiVersionThreshold {
+		warnings = append(warnings, fmt.Sprintf("The api-version %s is more than %d years behind the latest api-version %s.", apiVersion, int(OutdatedApiVersionThreshold.Hours()/24/365), latest))
+	}
+	return warnings
+}
+
+// IsPreviewApiVersion checks whether the api-version is a preview version, e.g. `2023-01-01-preview` or `2023-01-01-beta`
+func IsPreviewApiVersion(apiVersion string) bool {
+	lower := strings.ToLower(apiVersion)
+	return strings.HasSuffix(lower, "-preview") || strings.HasSuffix(lower, "-privatepreview") || strings.HasSuffix(lower, "-beta") || strings.HasSuffix(lower, "-alpha")
+}
+
+func apiVersionDate(apiVersion string) (time.Time, bool) {
+	if len(apiVersion) < len("2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006-01-02", apiVersion[:len("2006-01-02")])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
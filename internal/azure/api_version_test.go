@@ -0,0 +1,77 @@
+package azure
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ApiVersionWarnings(t *testing.T) {
+	testcases := []struct {
+		ApiVersion  string
+		ApiVersions []string
+		Expected    []string
+	}{
+		{
+			ApiVersion:  "2023-01-01",
+			ApiVersions: []string{"2022-01-01", "2023-01-01", "2024-01-01-preview"},
+			Expected:    []string{},
+		},
+		{
+			ApiVersion:  "2023-01-01-preview",
+			ApiVersions: []string{"2022-01-01", "2023-01-01-preview", "2023-06-01"},
+			Expected:    []string{"The api-version 2023-01-01-preview is a preview version, and the stable api-version 2023-06-01 is available."},
+		},
+		{
+			// the stable api-version is older than the preview api-version
+			ApiVersion:  "2023-01-01-preview",
+			ApiVersions: []string{"2022-01-01", "2023-01-01-preview"},
+			Expected:    []string{},
+		},
+		{
+			ApiVersion:  "2019-01-01",
+			ApiVersions: []string{"2019-01-01", "2021-01-01", "2024-01-01", "2025-01-01-preview"},
+			Expected:    []string{"The api-version 2019-01-01 is more than 2 years behind the latest api-version 2024-01-01."},
+		},
+		{
+			ApiVersion:  "2019-01-01-preview",
+			ApiVersions: []string{"2019-01-01-preview", "2024-01-01"},
+			Expected: []string{
+				"The api-version 2019-01-01-preview is a preview version, and the stable api-version 2024-01-01 is available.",
+				"The api-version 2019-01-01-preview is more than 2 years behind the latest api-version 2024-01-01.",
+			},
+		},
+		{
+			// only preview api-versions are available
+			ApiVersion:  "2019-01-01-preview",
+			ApiVersions: []string{"2019-01-01-preview", "2024-01-01-preview"},
+			Expected:    []string{"The api-version 2019-01-01-preview is more than 2 years behind the latest api-version 2024-01-01-preview."},
+		},
+		{
+			ApiVersion:  "7.4",
+			ApiVersions: []string{"7.4"},
+			Expected:    nil,
+		},
+	}
+
+	for _, testcase := range testcases {
+		actual := apiVersionWarnings(testcase.ApiVersion, testcase.ApiVersions)
+		if !reflect.DeepEqual(actual, testcase.Expected) {
+			t.Errorf("Expected %v, but got %v for api-version %s", testcase.Expected, actual, testcase.ApiVersion)
+		}
+	}
+}
+
+func Test_IsPreviewApiVersion(t *testing.T) {
+	testcases := map[string]bool{
+		"2023-01-01":                false,
+		"2023-01-01-preview":        true,
+		"2023-01-01-Preview":        true,
+		"2023-01-01-privatepreview": true,
+		"2023-01-01-beta":           true,
+	}
+	for apiVersion, expected := range testcases {
+		if actual := IsPreviewApiVersion(apiVersion); actual != expected {
+			t.Errorf("Expected %v, but got %v for api-version %s", expected, actual, apiVersion)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package azure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+// CompatibilityIssue describes a property in the body which isn't compatible with the new api-version
+type CompatibilityIssue struct {
+	Path    string
+	Message string
+}
+
+func (i CompatibilityIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// CompareBodyCompatibility compares the resource definitions of the old and new api-versions, and returns the issues of the properties
+// defined in the body, e.g. the property is removed, its type is changed or it's read-only in the new api-version.
+func CompareBodyCompatibility(oldDef *types.ResourceType, newDef *types.ResourceType, body interface{}) []CompatibilityIssue {
+	if oldDef == nil || newDef == nil || oldDef.Body == nil || newDef.Body == nil || body == nil {
+		return nil
+	}
+	issues := compareTypeCompatibility(oldDef.Body.Type, newDef.Body.Type, body, "")
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+	return issues
+}
+
+func compareTypeCompatibility(oldType *types.TypeBase, newType *types.TypeBase, body interface{}, path string) []CompatibilityIssue {
+	if oldType == nil || newType == nil || body == nil {
+		return nil
+	}
+
+	oldKind, newKind := typeKind(*oldType), typeKind(*newType)
+	if oldKind != newKind && oldKind != "any" && newKind != "any" {
+		return []CompatibilityIssue{{Path: path, Message: fmt.Sprintf("type changed from %s to %s", oldKind, newKind)}}
+	}
+
+	switch v := body.(type) {
+	case map[string]interface{}:
+		oldProperties, oldAdditional := objectProperties(*oldType, v)
+		newProperties, newAdditional := objectProperties(*newType, v)
+		if oldProperties == nil || newProperties == nil {
+			return nil
+		}
+		issues := make([]CompatibilityIssue, 0)
+		for key, value := range v {
+			propertyPath := key
+			if path != "" {
+				propertyPath = path + "." + key
+			}
+
+			oldProperty, inOld := oldProperties[key]
+			newProperty, inNew := newProperties[key]
+			switch {
+			case !inNew && newAdditional == nil:
+				if renamed := findPropertyIgnoreCase(newProperties, key); renamed != "" {
+					issues = append(issues, CompatibilityIssue{Path: propertyPath, Message: fmt.Sprintf("property renamed to %s", renamed)})
+				} else {
+					issues = append(issues, CompatibilityIssue{Path: propertyPath, Message: "property removed"})
+				}
+			case !inNew:
+				var oldPropertyType *types.TypeBase
+				if inOld && oldProperty.Type != nil {
+					oldPropertyType = oldProperty.Type.Type
+				} else if oldAdditional != nil {
+					oldPropertyType = oldAdditional.Type
+				}
+				issues = append(issues, compareTypeCompatibility(oldPropertyType, newAdditional.Type, value, propertyPath)...)
+			case newProperty.IsReadOnly() && (!inOld || !oldProperty.IsReadOnly()):
+				issues = append(issues, CompatibilityIssue{Path: propertyPath, Message: "now read-only"})
+			case inOld && oldProperty.Type != nil && newProperty.Type != nil:
+				issues = append(issues, compareTypeCompatibility(oldProperty.Type.Type, newProperty.Type.Type, value, propertyPath)...)
+			}
+		}
+		return issues
+	case []interface{}:
+		oldArray, ok := (*oldType).(*types.ArrayType)
+		if !ok || oldArray.ItemType == nil {
+			return nil
+		}
+		newArray, ok := (*newType).(*types.ArrayType)
+		if !ok || newArray.ItemType == nil {
+			return nil
+		}
+		issues := make([]CompatibilityIssue, 0)
+		for index, item := range v {
+			issues = append(issues, compareTypeCompatibility(oldArray.ItemType.Type, newArray.ItemType.Type, item, fmt.Sprintf("%s[%d]", path, index))...)
+		}
+		return issues
+	}
+	return nil
+}
+
+// objectProperties returns the properties and the additional properties type of the object type,
+// the properties of the discriminated object type are resolved by the discriminator in the body.
+func objectProperties(t types.TypeBase, body map[string]interface{}) (map[string]types.ObjectProperty, *types.TypeReference) {
+	switch v := t.(type) {
+	case *types.ObjectType:
+		if v == nil {
+			return nil, nil
+		}
+		return v.Properties, v.AdditionalProperties
+	case *types.DiscriminatedObjectType:
+		if v == nil {
+			return nil, nil
+		}
+		properties := make(map[string]types.ObjectProperty)
+		for key, value := range v.BaseProperties {
+			properties[key] = value
+		}
+		discriminator, ok := body[v.Discriminator].(string)
+		if !ok || v.Elements[discriminator] == nil || v.Elements[discriminator].Type == nil {
+			return nil, nil
+		}
+		elementProperties, additional := objectProperties(*v.Elements[discriminator].Type, body)
+		for key, value := range elementProperties {
+			properties[key] = value
+		}
+		return properties, additional
+	case *types.ResourceType:
+		if v == nil || v.Body == nil || v.Body.Type == nil {
+			return nil, nil
+		}
+		return objectProperties(*v.Body.Type, body)
+	}
+	return nil, nil
+}
+
+func findPropertyIgnoreCase(properties map[string]types.ObjectProperty, key string) string {
+	for name := range properties {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+	}
+	return ""
+}
+
+// typeKind returns the JSON kind of the type, the string literals and unions of string literals are strings.
+func typeKind(t types.TypeBase) string {
+	switch v := t.(type) {
+	case *types.ObjectType, *types.DiscriminatedObjectType, *types.ResourceType:
+		return "object"
+	case *types.ArrayType:
+		return "array"
+	case *types.StringType, *types.StringLiteralType:
+		return "string"
+	case *types.IntegerType:
+		return "integer"
+	case *types.BooleanType:
+		return "boolean"
+	case *types.UnionType:
+		kind := ""
+		for _, element := range v.Elements {
+			if element == nil || element.Type == nil {
+				return "any"
+			}
+			elementKind := typeKind(*element.Type)
+			if kind != "" && kind != elementKind {
+				return "any"
+			}
+			kind = elementKind
+		}
+		if kind == "" {
+			return "any"
+		}
+		return kind
+	}
+	return "any"
+}
@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_CompareBodyCompatibility(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	integerType := &types.TypeReference{Type: (&types.IntegerType{}).AsTypeBase()}
+	tagsType := &types.TypeReference{Type: (&types.ObjectType{AdditionalProperties: stringType}).AsTypeBase()}
+
+	oldDef := &types.ResourceType{
+		Body: &types.TypeReference{Type: (&types.ObjectType{
+			Properties: map[string]types.ObjectProperty{
+				"tags": {Type: tagsType},
+				"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"removed":   {Type: stringType},
+						"renamedId": {Type: stringType},
+						"changed":   {Type: stringType},
+						"readOnly":  {Type: stringType},
+						"unchanged": {Type: stringType},
+						"items": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+							Properties: map[string]types.ObjectProperty{
+								"size": {Type: stringType},
+							},
+						}).AsTypeBase()}}).AsTypeBase()}},
+					},
+				}).AsTypeBase()}},
+			},
+		}).AsTypeBase()},
+	}
+
+	newDef := &types.ResourceType{
+		Body: &types.TypeReference{Type: (&types.ObjectType{
+			Properties: map[string]types.ObjectProperty{
+				"tags": {Type: tagsType},
+				"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"renamedID": {Type: stringType},
+						"changed":   {Type: integerType},
+						"readOnly":  {Type: stringType, Flags: []types.ObjectPropertyFlag{types.ReadOnly}},
+						"unchanged": {Type: stringType},
+						"items": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+							Properties: map[string]types.ObjectProperty{
+								"size": {Type: integerType},
+							},
+						}).AsTypeBase()}}).AsTypeBase()}},
+					},
+				}).AsTypeBase()}},
+			},
+		}).AsTypeBase()},
+	}
+
+	body := map[string]interface{}{
+		"tags": map[string]interface{}{
+			"env": "test",
+		},
+		"properties": map[string]interface{}{
+			"removed":   "foo",
+			"renamedId": "foo",
+			"changed":   "foo",
+			"readOnly":  "foo",
+			"unchanged": "foo",
+			"items": []interface{}{
+				map[string]interface{}{
+					"size": "small",
+				},
+			},
+		},
+	}
+
+	expected := []CompatibilityIssue{
+		{Path: "properties.changed", Message: "type changed from string to integer"},
+		{Path: "properties.items[0].size", Message: "type changed from string to integer"},
+		{Path: "properties.readOnly", Message: "now read-only"},
+		{Path: "properties.removed", Message: "property removed"},
+		{Path: "properties.renamedId", Message: "property renamed to renamedID"},
+	}
+	actual := CompareBodyCompatibility(oldDef, newDef, body)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, but got %v", expected, actual)
+	}
+
+	if actual := CompareBodyCompatibility(oldDef, oldDef, body); len(actual) != 0 {
+		t.Errorf("Expected no issues for the same resource definition, but got %v", actual)
+	}
+
+	if actual := CompareBodyCompatibility(nil, newDef, body); len(actual) != 0 {
+		t.Errorf("Expected no issues without the old resource definition, but got %v", actual)
+	}
+}
@@ -0,0 +1,135 @@
+package azure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/Azure/terraform-provider-azapi/utils"
+)
+
+// DeployTimeConstantChanges returns the paths of the properties which are deploy-time constants in the resource definition and whose values are changed
+// from the old body to the new body. These properties can only be set when the resource is created, the properties absent from either body are skipped.
+// The array items are matched by their identifiers in the same way as reading the body, and the string values are compared case-insensitively if ignoreCasing is true.
+func DeployTimeConstantChanges(def *types.ResourceType, oldBody interface{}, newBody interface{}, ignoreCasing bool) []string {
+	if def == nil || def.Body == nil {
+		return nil
+	}
+	paths := deployTimeConstantChanges(def.Body.Type, oldBody, newBody, "", ignoreCasing)
+	sort.Strings(paths)
+	return paths
+}
+
+func deployTimeConstantChanges(t *types.TypeBase, oldValue interface{}, newValue interface{}, path string, ignoreCasing bool) []string {
+	if t == nil || oldValue == nil || newValue == nil {
+		return nil
+	}
+
+	switch v := newValue.(type) {
+	case map[string]interface{}:
+		oldMap, ok := oldValue.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		properties, additional := objectProperties(*t, v)
+		paths := make([]string, 0)
+		for key, value := range v {
+			oldPropertyValue, ok := oldMap[key]
+			if !ok {
+				continue
+			}
+			propertyPath := key
+			if path != "" {
+				propertyPath = path + "." + key
+			}
+
+			if property, ok := properties[key]; ok {
+				if property.IsDeployTimeConstant() && !isDeployTimeConstantEqual(property.Type, oldPropertyValue, value, ignoreCasing) {
+					paths = append(paths, propertyPath)
+					continue
+				}
+				if property.Type != nil {
+					paths = append(paths, deployTimeConstantChanges(property.Type.Type, oldPropertyValue, value, propertyPath, ignoreCasing)...)
+				}
+			} else if additional != nil {
+				paths = append(paths, deployTimeConstantChanges(additional.Type, oldPropertyValue, value, propertyPath, ignoreCasing)...)
+			}
+		}
+		return paths
+	case []interface{}:
+		oldArr, ok := oldValue.([]interface{})
+		if !ok || len(oldArr) == 0 {
+			return nil
+		}
+		arrayType, ok := (*t).(*types.ArrayType)
+		if !ok || arrayType == nil || arrayType.ItemType == nil {
+			return nil
+		}
+		paths := make([]string, 0)
+		identifierKeys := utils.ArrayItemIdentifierKeys(oldArr, v, arrayType.ItemType.Type)
+		if !hasArrayItemIdentifiers(oldArr, identifierKeys) || !hasArrayItemIdentifiers(v, identifierKeys) {
+			// the items without identifiers are matched by the position, the added or removed items are skipped
+			for index := range v {
+				if index < len(oldArr) {
+					paths = append(paths, deployTimeConstantChanges(arrayType.ItemType.Type, oldArr[index], v[index], fmt.Sprintf("%s[%d]", path, index), ignoreCasing)...)
+				}
+			}
+			return paths
+		}
+		// the items are matched by the identifiers, so that the reordered items are compared with themselves
+		used := make([]bool, len(oldArr))
+		for index, item := range v {
+			identifier := utils.ArrayItemIdentifier(item, identifierKeys)
+			for oldIndex, oldItem := range oldArr {
+				if !used[oldIndex] && utils.ArrayItemIdentifier(oldItem, identifierKeys) == identifier {
+					used[oldIndex] = true
+					paths = append(paths, deployTimeConstantChanges(arrayType.ItemType.Type, oldItem, item, fmt.Sprintf("%s[%d]", path, index), ignoreCasing)...)
+					break
+				}
+			}
+		}
+		return paths
+	}
+	return nil
+}
+
+func hasArrayItemIdentifiers(input []interface{}, identifierKeys []string) bool {
+	for _, item := range input {
+		if utils.ArrayItemIdentifier(item, identifierKeys) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isDeployTimeConstantEqual compares the values of the deploy-time constant, the enum values are compared case-insensitively
+// because the service accepts them in any casing, and so are the other string values if ignoreCasing is true.
+func isDeployTimeConstantEqual(t *types.TypeReference, oldValue interface{}, newValue interface{}, ignoreCasing bool) bool {
+	oldString, oldOk := oldValue.(string)
+	newString, newOk := newValue.(string)
+	if oldOk && newOk && (ignoreCasing || (t != nil && isEnumType(t.Type))) {
+		return strings.EqualFold(oldString, newString)
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+func isEnumType(t *types.TypeBase) bool {
+	if t == nil || *t == nil {
+		return false
+	}
+	switch v := (*t).(type) {
+	case *types.StringLiteralType:
+		return true
+	case *types.UnionType:
+		for _, element := range v.Elements {
+			if element != nil && element.Type != nil {
+				if _, ok := (*element.Type).(*types.StringLiteralType); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
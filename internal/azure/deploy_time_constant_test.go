@@ -0,0 +1,158 @@
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_DeployTimeConstantChanges(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	enumType := &types.TypeReference{Type: (&types.UnionType{Elements: []*types.TypeReference{
+		{Type: (&types.StringLiteralType{Value: "Standard_LRS"}).AsTypeBase()},
+		{Type: (&types.StringLiteralType{Value: "Premium_LRS"}).AsTypeBase()},
+	}}).AsTypeBase()}
+	deployTimeConstant := []types.ObjectPropertyFlag{types.DeployTimeConstant}
+	def := &types.ResourceType{
+		Body: &types.TypeReference{Type: (&types.ObjectType{
+			Properties: map[string]types.ObjectProperty{
+				"kind": {Type: stringType, Flags: deployTimeConstant},
+				"sku":  {Type: enumType, Flags: deployTimeConstant},
+				"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"dnsPrefix":   {Type: stringType, Flags: deployTimeConstant},
+						"description": {Type: stringType},
+						"pools": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+							Properties: map[string]types.ObjectProperty{
+								"name":   {Type: stringType},
+								"vmSize": {Type: stringType, Flags: deployTimeConstant},
+								"count":  {Type: stringType},
+							},
+						}).AsTypeBase()}}).AsTypeBase()}},
+					},
+				}).AsTypeBase()}},
+			},
+		}).AsTypeBase()},
+	}
+
+	oldBody := map[string]interface{}{
+		"kind": "StorageV2",
+		"sku":  "Standard_LRS",
+		"properties": map[string]interface{}{
+			"dnsPrefix":   "foo",
+			"description": "foo",
+			"pools": []interface{}{
+				map[string]interface{}{"name": "a", "vmSize": "Standard_D2", "count": "1"},
+				map[string]interface{}{"name": "b", "vmSize": "Standard_D4", "count": "1"},
+			},
+		},
+	}
+
+	testcases := []struct {
+		Name         string
+		NewBody      interface{}
+		IgnoreCasing bool
+		Expected     []string
+	}{
+		{
+			Name: "deploy-time constants are changed",
+			NewBody: map[string]interface{}{
+				"kind": "BlobStorage",
+				"properties": map[string]interface{}{
+					"dnsPrefix":   "bar",
+					"description": "bar",
+					"pools": []interface{}{
+						map[string]interface{}{"name": "a", "vmSize": "Standard_D4", "count": "2"},
+					},
+				},
+			},
+			Expected: []string{"kind", "properties.dnsPrefix", "properties.pools[0].vmSize"},
+		},
+		{
+			Name: "only the other properties are changed",
+			NewBody: map[string]interface{}{
+				"kind": "StorageV2",
+				"properties": map[string]interface{}{
+					"dnsPrefix":   "foo",
+					"description": "bar",
+				},
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "enum values are changed in casing",
+			NewBody: map[string]interface{}{
+				"kind": "StorageV2",
+				"sku":  "standard_lrs",
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "enum values are changed",
+			NewBody: map[string]interface{}{
+				"kind": "storagev2",
+				"sku":  "Premium_LRS",
+			},
+			Expected: []string{"kind", "sku"},
+		},
+		{
+			Name: "array items are reordered",
+			NewBody: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"pools": []interface{}{
+						map[string]interface{}{"name": "b", "vmSize": "Standard_D4", "count": "1"},
+						map[string]interface{}{"name": "a", "vmSize": "Standard_D2", "count": "1"},
+					},
+				},
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "array item is added and the existing item is changed",
+			NewBody: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"pools": []interface{}{
+						map[string]interface{}{"name": "c", "vmSize": "Standard_D2", "count": "1"},
+						map[string]interface{}{"name": "a", "vmSize": "Standard_D8", "count": "1"},
+						map[string]interface{}{"name": "b", "vmSize": "Standard_D4", "count": "1"},
+					},
+				},
+			},
+			Expected: []string{"properties.pools[1].vmSize"},
+		},
+		{
+			Name: "string values are changed in casing",
+			NewBody: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"dnsPrefix": "FOO",
+				},
+			},
+			Expected: []string{"properties.dnsPrefix"},
+		},
+		{
+			Name: "string values are changed in casing and the casing is ignored",
+			NewBody: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"dnsPrefix": "FOO",
+				},
+			},
+			IgnoreCasing: true,
+			Expected:     []string{},
+		},
+		{
+			Name: "deploy-time constants are removed",
+			NewBody: map[string]interface{}{
+				"properties": map[string]interface{}{},
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		actual := DeployTimeConstantChanges(def, oldBody, testcase.NewBody, testcase.IgnoreCasing)
+		if !reflect.DeepEqual(actual, testcase.Expected) {
+			t.Errorf("%s: expected %v, but got %v", testcase.Name, testcase.Expected, actual)
+		}
+	}
+}
@@ -83,3 +83,28 @@ func GetResourceDefinition(resourceType, apiVersion string) (*types.ResourceType
 	}
 	return nil, fmt.Errorf("failed to find resource type %s api-version %s in azure schema index", resourceType, apiVersion)
 }
+
+func GetResourceFunctionDefinition(resourceType, actionName, apiVersion string) (*types.ResourceFunctionType, error) {
+	azureSchema := GetAzureSchema()
+	if azureSchema == nil {
+		return nil, fmt.Errorf("failed to load azure schema index")
+	}
+	for key, value := range azureSchema.Functions {
+		if !strings.EqualFold(key, resourceType) {
+			continue
+		}
+		for _, v := range value.Definitions {
+			if v.ApiVersion != apiVersion {
+				continue
+			}
+			def, err := v.GetDefinition()
+			if err != nil {
+				return nil, err
+			}
+			if def != nil && strings.EqualFold(def.Name, actionName) {
+				return def, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("failed to find resource function %s of resource type %s api-version %s in azure schema index", actionName, resourceType, apiVersion)
+}
@@ -64,3 +64,18 @@ func Test_AllBicepTypes(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetResourceFunctionDefinition(t *testing.T) {
+	def, err := azure.GetResourceFunctionDefinition("Microsoft.Automation/automationAccounts", "convertGraphRunbookContent", "2023-11-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def == nil || def.Input == nil || def.Input.Type == nil {
+		t.Fatalf("expect the input type of the resource function is resolved")
+	}
+
+	_, err = azure.GetResourceFunctionDefinition("Microsoft.Automation/automationAccounts", "notExist", "2023-11-01")
+	if err == nil {
+		t.Errorf("expect error when resource function doesn't exist")
+	}
+}
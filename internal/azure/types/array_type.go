@@ -2,7 +2,6 @@ package types
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure/utils"
 )
@@ -63,6 +62,9 @@ func (t *ArrayType) GetWriteOnly(body interface{}) interface{} {
 }
 
 func (t *ArrayType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
@@ -89,7 +91,7 @@ func (t *ArrayType) Validate(body interface{}, path string) []error {
 
 	for index, value := range bodyArray {
 		if itemType != nil {
-			errors = append(errors, (*itemType).Validate(value, path+"."+strconv.Itoa(index))...)
+			errors = append(errors, (*itemType).Validate(value, fmt.Sprintf("%s[%d]", path, index))...)
 		}
 	}
 	return errors
@@ -111,6 +111,9 @@ func (t *DiscriminatedObjectType) GetWriteOnly(body interface{}) interface{} {
 }
 
 func (t *DiscriminatedObjectType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
@@ -156,6 +159,11 @@ func (t *DiscriminatedObjectType) Validate(body interface{}, path string) []erro
 		return errors
 	}
 
+	// the discriminated object can't be determined if the discriminator is unknown
+	if IsUnknownValue(otherProperties[t.Discriminator]) {
+		return errors
+	}
+
 	if discriminator, ok := otherProperties[t.Discriminator].(string); ok {
 		switch {
 		case t.Elements[discriminator] == nil:
@@ -174,6 +182,19 @@ func (t *DiscriminatedObjectType) Validate(body interface{}, path string) []erro
 	return errors
 }
 
+// matchesDiscriminator checks whether the discriminator of the value is one of the discriminated object types
+func (t *DiscriminatedObjectType) matchesDiscriminator(body interface{}) bool {
+	if t == nil {
+		return false
+	}
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	discriminator, ok := bodyMap[t.Discriminator].(string)
+	return ok && t.Elements[discriminator] != nil
+}
+
 func (t *DiscriminatedObjectType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
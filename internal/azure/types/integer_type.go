@@ -27,6 +27,9 @@ func (t *IntegerType) AsTypeBase() *TypeBase {
 }
 
 func (t *IntegerType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if body == nil {
 		return nil
 	}
@@ -99,6 +99,9 @@ func (t *ObjectType) GetWriteOnly(body interface{}) interface{} {
 }
 
 func (t *ObjectType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
@@ -128,7 +131,7 @@ func (t *ObjectType) Validate(body interface{}, path string) []error {
 		} else {
 			options := make([]string, 0)
 			for key := range t.Properties {
-				options = append(options, path+"."+key)
+				options = append(options, key)
 			}
 			errors = append(errors, utils.ErrorShouldNotDefine(path+"."+key, options))
 		}
@@ -188,6 +191,15 @@ func (o ObjectProperty) IsDeployTimeConstant() bool {
 	return false
 }
 
+func (o ObjectProperty) IsIdentifier() bool {
+	for _, value := range o.Flags {
+		if value == Identifier {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *ObjectProperty) UnmarshalJSON(body []byte) error {
 	var m map[string]*json.RawMessage
 	err := json.Unmarshal(body, &m)
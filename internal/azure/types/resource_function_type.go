@@ -11,19 +11,42 @@ type ResourceFunctionType struct {
 	Output       *TypeReference `json:"output"`
 }
 
-func (t ResourceFunctionType) GetReadOnly(i interface{}) interface{} {
+func (t *ResourceFunctionType) GetReadOnly(i interface{}) interface{} {
+	if t == nil || i == nil {
+		return nil
+	}
+	if t.Output != nil && t.Output.Type != nil {
+		return (*t.Output.Type).GetReadOnly(i)
+	}
 	return i
 }
 
-func (t ResourceFunctionType) AsTypeBase() *TypeBase {
+func (t *ResourceFunctionType) AsTypeBase() *TypeBase {
 	typeBase := TypeBase(t)
 	return &typeBase
 }
 
-func (t ResourceFunctionType) Validate(body interface{}, path string) []error {
-	return []error{}
+// Validate validates the request body of the function against its input type.
+func (t *ResourceFunctionType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
+	if t == nil || body == nil {
+		return []error{}
+	}
+	errors := make([]error, 0)
+	if t.Input != nil && t.Input.Type != nil {
+		errors = append(errors, (*t.Input.Type).Validate(body, path)...)
+	}
+	return errors
 }
 
-func (t ResourceFunctionType) GetWriteOnly(body interface{}) interface{} {
+func (t *ResourceFunctionType) GetWriteOnly(body interface{}) interface{} {
+	if t == nil || body == nil {
+		return nil
+	}
+	if t.Input != nil && t.Input.Type != nil {
+		return (*t.Input.Type).GetWriteOnly(body)
+	}
 	return body
 }
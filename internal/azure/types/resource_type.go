@@ -37,6 +37,9 @@ func (t *ResourceType) GetWriteOnly(body interface{}) interface{} {
 }
 
 func (t *ResourceType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
@@ -28,13 +28,16 @@ func (t *StringLiteralType) GetWriteOnly(i interface{}) interface{} {
 }
 
 func (t *StringLiteralType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
 	errors := make([]error, 0)
 	if stringValue, ok := body.(string); ok {
 		if stringValue != t.Value {
-			errors = append(errors, utils.ErrorMismatch(path, t.Value, stringValue))
+			errors = append(errors, utils.ErrorNotMatchAnyValues(path, stringValue, []string{t.Value}))
 		}
 	} else {
 		errors = append(errors, utils.ErrorMismatch(path, "string", fmt.Sprintf("%T", body)))
@@ -15,7 +15,9 @@ func (t *UnionType) GetReadOnly(i interface{}) interface{} {
 	if t == nil || i == nil {
 		return nil
 	}
-	// TODO: improve this
+	if element := t.selectElement(i); element != nil {
+		return (*element).GetReadOnly(i)
+	}
 	return i
 }
 
@@ -23,11 +25,116 @@ func (t *UnionType) GetWriteOnly(body interface{}) interface{} {
 	if t == nil || body == nil {
 		return nil
 	}
-	// TODO: improve this
+	if element := t.selectElement(body); element != nil {
+		return (*element).GetWriteOnly(body)
+	}
 	return body
 }
 
+// selectElement selects the element type which the value belongs to. The discriminated object type whose discriminator matches the value is preferred,
+// then the element type which the value is valid against, then the element type which has the most properties matching the value.
+// It returns nil if none of the element types matches the value.
+func (t *UnionType) selectElement(body interface{}) *TypeBase {
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if discriminatedObjectType, ok := (*element.Type).(*DiscriminatedObjectType); ok && discriminatedObjectType.matchesDiscriminator(body) {
+			return element.Type
+		}
+	}
+
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if len((*element.Type).Validate(body, "")) == 0 {
+			return element.Type
+		}
+	}
+
+	var selected *TypeBase
+	bestScore := -1
+	for _, element := range t.Elements {
+		if element == nil || element.Type == nil {
+			continue
+		}
+		if score := matchScore(*element.Type, body); score > bestScore {
+			selected, bestScore = element.Type, score
+		}
+	}
+	return selected
+}
+
+// matchScore returns how well the value matches the type, it's negative if the value doesn't match the type
+func matchScore(t TypeBase, body interface{}) int {
+	switch v := t.(type) {
+	case *ObjectType:
+		bodyMap, ok := body.(map[string]interface{})
+		if !ok || v == nil {
+			return -1
+		}
+		score := 0
+		for key := range bodyMap {
+			if _, ok := v.Properties[key]; ok || v.AdditionalProperties != nil {
+				score++
+			}
+		}
+		return score
+	case *DiscriminatedObjectType:
+		bodyMap, ok := body.(map[string]interface{})
+		if !ok || v == nil {
+			return -1
+		}
+		score := 0
+		for key := range bodyMap {
+			if _, ok := v.BaseProperties[key]; ok {
+				score++
+			}
+		}
+		return score
+	case *UnionType:
+		score := -1
+		if v == nil {
+			return score
+		}
+		for _, element := range v.Elements {
+			if element != nil && element.Type != nil {
+				score = max(score, matchScore(*element.Type, body))
+			}
+		}
+		return score
+	case *ArrayType:
+		if _, ok := body.([]interface{}); ok {
+			return 0
+		}
+	case *StringType:
+		if _, ok := body.(string); ok {
+			return 0
+		}
+	case *StringLiteralType:
+		if value, ok := body.(string); ok && v != nil && value == v.Value {
+			return 1
+		}
+	case *IntegerType:
+		switch body.(type) {
+		case float64, float32, int64, int32, int:
+			return 0
+		}
+	case *BooleanType:
+		if _, ok := body.(bool); ok {
+			return 0
+		}
+	case *AnyType:
+		return 0
+	}
+	return -1
+}
+
 func (t *UnionType) Validate(body interface{}, path string) []error {
+	if IsUnknownValue(body) {
+		return nil
+	}
 	if t == nil || body == nil {
 		return []error{}
 	}
@@ -0,0 +1,12 @@
+package types
+
+// UnknownValue is the marker of the values which are unknown during the plan, e.g. the values referencing other resources' attributes.
+// It's a string which can't be specified in the configuration, so it survives the JSON round trip of the body.
+// The unknown values are treated as wildcards in the validation, the known parts of the body are still validated.
+const UnknownValue = "\x00azapi:unknown\x00"
+
+// IsUnknownValue checks whether the value is the marker of the unknown values
+func IsUnknownValue(value interface{}) bool {
+	v, ok := value.(string)
+	return ok && v == UnknownValue
+}
@@ -0,0 +1,90 @@
+package azure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_UnionTypeReadOnlyAndWriteOnly(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	readOnly := []types.ObjectPropertyFlag{types.ReadOnly}
+	def := (&types.UnionType{Elements: []*types.TypeReference{
+		{Type: (&types.ObjectType{
+			Properties: map[string]types.ObjectProperty{
+				"kind":   {Type: stringType},
+				"name":   {Type: stringType},
+				"status": {Type: stringType, Flags: readOnly},
+			},
+		}).AsTypeBase()},
+		{Type: (&types.DiscriminatedObjectType{
+			Discriminator: "type",
+			BaseProperties: map[string]types.ObjectProperty{
+				"type": {Type: stringType},
+			},
+			Elements: map[string]*types.TypeReference{
+				"Vnet": {Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"subnetId": {Type: stringType},
+						"state":    {Type: stringType, Flags: readOnly},
+					},
+				}).AsTypeBase()},
+			},
+		}).AsTypeBase()},
+	}}).AsTypeBase()
+
+	testcases := []struct {
+		Name              string
+		Body              interface{}
+		ExpectedReadOnly  interface{}
+		ExpectedWriteOnly interface{}
+	}{
+		{
+			Name: "discriminated object is preferred",
+			Body: map[string]interface{}{
+				"type":     "Vnet",
+				"subnetId": "foo",
+				"state":    "Succeeded",
+			},
+			ExpectedReadOnly: map[string]interface{}{
+				"type":  "Vnet",
+				"state": "Succeeded",
+			},
+			ExpectedWriteOnly: map[string]interface{}{
+				"type":     "Vnet",
+				"subnetId": "foo",
+			},
+		},
+		{
+			Name: "object matching the most properties",
+			Body: map[string]interface{}{
+				"kind":   "foo",
+				"name":   "bar",
+				"status": "Succeeded",
+			},
+			ExpectedReadOnly: map[string]interface{}{
+				"status": "Succeeded",
+			},
+			ExpectedWriteOnly: map[string]interface{}{
+				"kind": "foo",
+				"name": "bar",
+			},
+		},
+		{
+			Name:              "no matching element",
+			Body:              "foo",
+			ExpectedReadOnly:  "foo",
+			ExpectedWriteOnly: "foo",
+		},
+	}
+
+	for _, testcase := range testcases {
+		if actual := (*def).GetReadOnly(testcase.Body); !reflect.DeepEqual(actual, testcase.ExpectedReadOnly) {
+			t.Errorf("%s: expected read-only %v, but got %v", testcase.Name, testcase.ExpectedReadOnly, actual)
+		}
+		if actual := (*def).GetWriteOnly(testcase.Body); !reflect.DeepEqual(actual, testcase.ExpectedWriteOnly) {
+			t.Errorf("%s: expected write-only %v, but got %v", testcase.Name, testcase.ExpectedWriteOnly, actual)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_ValidateUnknownValues(t *testing.T) {
+	minLength := 3
+	stringType := &types.TypeReference{Type: (&types.StringType{MinLength: &minLength}).AsTypeBase()}
+	skuType := &types.TypeReference{Type: (&types.UnionType{Elements: []*types.TypeReference{
+		{Type: (&types.StringLiteralType{Value: "Basic"}).AsTypeBase()},
+		{Type: (&types.StringLiteralType{Value: "Standard"}).AsTypeBase()},
+	}}).AsTypeBase()}
+	def := (&types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"name": {Type: stringType, Flags: []types.ObjectPropertyFlag{types.Required}},
+			"sku":  {Type: skuType},
+			"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"subnetId": {Type: stringType},
+					"count":    {Type: &types.TypeReference{Type: (&types.IntegerType{}).AsTypeBase()}},
+					"items":    {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: stringType}).AsTypeBase()}},
+				},
+			}).AsTypeBase()}},
+		},
+	}).AsTypeBase()
+
+	testcases := []struct {
+		Name        string
+		Body        interface{}
+		ExpectedErr int
+	}{
+		{
+			Name: "unknown leaves are wildcards",
+			Body: map[string]interface{}{
+				"name": types.UnknownValue,
+				"sku":  types.UnknownValue,
+				"properties": map[string]interface{}{
+					"subnetId": types.UnknownValue,
+					"count":    types.UnknownValue,
+					"items":    types.UnknownValue,
+				},
+			},
+			ExpectedErr: 0,
+		},
+		{
+			Name: "unknown object",
+			Body: map[string]interface{}{
+				"name":       "foo",
+				"properties": types.UnknownValue,
+			},
+			ExpectedErr: 0,
+		},
+		{
+			Name: "known parts are validated",
+			Body: map[string]interface{}{
+				"name": types.UnknownValue,
+				"sku":  "Premium",
+				"properties": map[string]interface{}{
+					"subnetId": types.UnknownValue,
+					"unknown":  types.UnknownValue,
+					"items":    []interface{}{types.UnknownValue, "a"},
+				},
+			},
+			ExpectedErr: 3,
+		},
+		{
+			Name: "empty strings are validated",
+			Body: map[string]interface{}{
+				"name": "",
+			},
+			ExpectedErr: 1,
+		},
+	}
+
+	for _, testcase := range testcases {
+		errors := (*def).Validate(testcase.Body, "")
+		if len(errors) != testcase.ExpectedErr {
+			t.Errorf("%s: expected %d errors, but got %d: %v", testcase.Name, testcase.ExpectedErr, len(errors), errors)
+		}
+	}
+}
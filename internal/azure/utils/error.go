@@ -2,81 +2,78 @@ package utils
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/agext/levenshtein"
 )
 
 func ErrorCommon(key string, message string) error {
-	return fmt.Errorf("`%s` is invalid, %s", strings.TrimPrefix(key, "."), message)
+	return fmt.Errorf("`%s` is invalid, %s", attributePath(key), message)
 }
 
 func ErrorMismatch(key, expected, actual string) error {
-	return fmt.Errorf("`%s` is invalid, expect `%s` but got `%s`", strings.TrimPrefix(key, "."), expected, actual)
+	return fmt.Errorf("`%s` is invalid, expect `%s` but got `%s`", attributePath(key), expected, actual)
 }
 
 func ErrorNotMatchAny(key string) error {
-	return fmt.Errorf("`%s` doesn't match any accepted values", strings.TrimPrefix(key, "."))
+	return fmt.Errorf("`%s` doesn't match any accepted values", attributePath(key))
 }
 
 func ErrorNotMatchAnyValues(key string, value string, options []string) error {
-	suggestion := getSuggestion(value, options)
-	return fmt.Errorf("`%s`'s value `%s` is invalid. The supported values are [%s]. Do you mean `%s`? ",
-		strings.TrimPrefix(key, "."),
-		value,
-		strings.Join(options, ", "),
-		suggestion)
+	options = sortedOptions(options)
+	message := fmt.Sprintf("`%s`'s value `%s` is invalid. The supported values are [%s].", attributePath(key), value, strings.Join(options, ", "))
+	if suggestion := getSuggestion(value, options); suggestion != "" {
+		message += fmt.Sprintf(" Did you mean `%s`?", suggestion)
+	}
+	return fmt.Errorf("%s", message)
 }
 
 func ErrorShouldNotDefineReadOnly(key string) error {
-	return fmt.Errorf("`%s` is not expected here, it's read only", strings.TrimPrefix(key, "."))
+	return fmt.Errorf("`%s` is not expected here, it's read only", attributePath(key))
 }
 
+// ErrorShouldNotDefine returns the error of the property which isn't defined in the schema, the options are the names of the supported properties.
 func ErrorShouldNotDefine(key string, options []string) error {
-	suggestion := getSuggestion(key, options)
-	return fmt.Errorf("`%s` is not expected here. Do you mean `%s`? ", strings.TrimPrefix(key, "."), strings.TrimPrefix(suggestion, "."))
+	options = sortedOptions(options)
+	name := key[strings.LastIndex(key, ".")+1:]
+	message := fmt.Sprintf("`%s` is not expected here. The supported properties are [%s].", attributePath(key), strings.Join(options, ", "))
+	if suggestion := getSuggestion(name, options); suggestion != "" {
+		message += fmt.Sprintf(" Did you mean `%s`?", suggestion)
+	}
+	return fmt.Errorf("%s", message)
 }
 
 func ErrorShouldDefine(key string) error {
-	return fmt.Errorf("`%s` is required, but no definition was found", strings.TrimPrefix(key, "."))
+	return fmt.Errorf("`%s` is required, but no definition was found", attributePath(key))
+}
+
+// attributePath converts the path in the body, e.g. `.properties.subnets[0].name`, to the Terraform attribute path, e.g. `body.properties.subnets[0].name`
+func attributePath(key string) string {
+	return "body" + key
+}
+
+func sortedOptions(options []string) []string {
+	out := make([]string, len(options))
+	copy(out, options)
+	sort.Strings(out)
+	return out
 }
 
+// getSuggestion returns the option which is the closest to the value, the case differences are ignored.
+// It returns an empty string if none of the options is close enough to the value.
 func getSuggestion(value string, options []string) string {
 	suggestion := ""
-	distance := 1 << 16
+	distance := -1
 	for _, option := range options {
-		if dist := editDistance(value, option); dist < distance {
+		dist := levenshtein.Distance(strings.ToLower(value), strings.ToLower(option), nil)
+		if dist > max(len(value), len(option))/2 {
+			continue
+		}
+		if distance == -1 || dist < distance {
 			distance = dist
 			suggestion = option
 		}
 	}
 	return suggestion
 }
-
-func editDistance(a, b string) int {
-	n, m := len(a), len(b)
-	f := make([][]int, n+1)
-	for i := range f {
-		f[i] = make([]int, m+1, 1<<16)
-	}
-	for i := 1; i <= n; i++ {
-		for j := 1; j <= m; j++ {
-			f[i][j] = 1 << 16
-		}
-	}
-	for i := 1; i <= n; i++ {
-		for j := 1; j <= m; j++ {
-			if a[i-1] == b[j-1] {
-				f[i][j] = f[i-1][j-1]
-			}
-			if f[i][j] > f[i-1][j]+1 {
-				f[i][j] = f[i-1][j] + 1
-			}
-			if f[i][j] > f[i][j-1]+1 {
-				f[i][j] = f[i][j-1] + 1
-			}
-			if f[i][j] > f[i-1][j-1]+1 {
-				f[i][j] = f[i-1][j-1] + 1
-			}
-		}
-	}
-	return f[n][m]
-}
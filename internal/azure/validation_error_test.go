@@ -0,0 +1,75 @@
+package azure
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
+)
+
+func Test_ValidationErrorMessages(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	skuType := &types.TypeReference{Type: (&types.UnionType{Elements: []*types.TypeReference{
+		{Type: (&types.StringLiteralType{Value: "Standard"}).AsTypeBase()},
+		{Type: (&types.StringLiteralType{Value: "Basic"}).AsTypeBase()},
+	}}).AsTypeBase()}
+	def := (&types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"sku":  {Type: skuType},
+			"tier": {Type: &types.TypeReference{Type: (&types.StringLiteralType{Value: "Premium"}).AsTypeBase()}},
+			"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"subnets": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+						Properties: map[string]types.ObjectProperty{
+							"addressPrefix": {Type: stringType},
+							"name":          {Type: stringType},
+						},
+					}).AsTypeBase()}}).AsTypeBase()}},
+				},
+			}).AsTypeBase()}},
+			"source": {Type: &types.TypeReference{Type: (&types.DiscriminatedObjectType{
+				Discriminator: "kind",
+				BaseProperties: map[string]types.ObjectProperty{
+					"name": {Type: stringType},
+				},
+				Elements: map[string]*types.TypeReference{
+					"Storage": {Type: (&types.ObjectType{}).AsTypeBase()},
+					"Vault":   {Type: (&types.ObjectType{}).AsTypeBase()},
+				},
+			}).AsTypeBase()}},
+		},
+	}).AsTypeBase()
+
+	body := map[string]interface{}{
+		"sku":  "standard",
+		"tier": "Premum",
+		"properties": map[string]interface{}{
+			"subnets": []interface{}{
+				map[string]interface{}{
+					"addressprefix": "10.0.0.0/24",
+				},
+			},
+		},
+		"source": map[string]interface{}{
+			"kind": "Storag",
+		},
+		"unrelated": "foo",
+	}
+
+	expected := []string{
+		"`body.properties.subnets[0].addressprefix` is not expected here. The supported properties are [addressPrefix, name]. Did you mean `addressPrefix`?",
+		"`body.sku`'s value `standard` is invalid. The supported values are [Basic, Standard]. Did you mean `Standard`?",
+		"`body.source.kind`'s value `Storag` is invalid. The supported values are [Storage, Vault]. Did you mean `Storage`?",
+		"`body.tier`'s value `Premum` is invalid. The supported values are [Premium]. Did you mean `Premium`?",
+		"`body.unrelated` is not expected here. The supported properties are [properties, sku, source, tier].",
+	}
+	actual := make([]string, 0)
+	for _, err := range (*def).Validate(body, "") {
+		actual = append(actual, err.Error())
+	}
+	sort.Strings(actual)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, but got %v", expected, actual)
+	}
+}
@@ -10,13 +10,13 @@ import (
 	"slices"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	armpolicy "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/policy"
 	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/cenkalti/backoff/v4"
@@ -43,6 +43,7 @@ type DataPlaneRequester interface {
 	Get(ctx context.Context, id parse.DataPlaneResourceId, options RequestOptions) (interface{}, error)
 	DeleteThenPoll(ctx context.Context, id parse.DataPlaneResourceId, options RequestOptions) (interface{}, error)
 	Action(ctx context.Context, resourceID string, action string, apiVersion string, method string, body interface{}, options RequestOptions) (interface{}, error)
+	List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error)
 }
 
 var (
@@ -116,7 +117,8 @@ func (client *DataPlaneClient) cachedPipeline(rawUrl string) (runtime.Pipeline,
 	cloud := client.clientOptions.Cloud
 	host := parsedUrl.Host
 	for name, serviceConfiguration := range cloud.Services {
-		if strings.HasSuffix(host, strings.TrimPrefix(serviceConfiguration.Endpoint, "https://")) {
+		endpoint := strings.TrimSuffix(strings.TrimPrefix(serviceConfiguration.Endpoint, "https://"), "/")
+		if endpoint != "" && strings.HasSuffix(host, endpoint) {
 			serviceName = name
 			break
 		}
@@ -174,9 +176,7 @@ func (client *DataPlaneClient) CreateOrUpdateThenPoll(ctx context.Context, id pa
 	// poll until done
 	pt, err := runtime.NewPoller[interface{}](resp, pipeline, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		return resp, err
 	}
 
@@ -261,9 +261,7 @@ func (client *DataPlaneClient) DeleteThenPoll(ctx context.Context, id parse.Data
 	// poll until done
 	pt, err := runtime.NewPoller[interface{}](resp, pipeline, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		return resp, err
 	}
 
@@ -279,7 +277,7 @@ func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, ac
 	// build request
 	urlPath := fmt.Sprintf("https://%s", resourceID)
 	if action != "" {
-		urlPath = fmt.Sprintf("%s/%s", resourceID, action)
+		urlPath = fmt.Sprintf("https://%s/%s", resourceID, action)
 	}
 	req, err := runtime.NewRequest(ctx, method, urlPath)
 	if err != nil {
@@ -318,9 +316,7 @@ func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, ac
 	// poll until done
 	pt, err := runtime.NewPoller[interface{}](resp, pipeline, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		return resp, err
 	}
 
@@ -343,6 +339,126 @@ func (client *DataPlaneClient) Action(ctx context.Context, resourceID string, ac
 	return responseBody, nil
 }
 
+func (client *DataPlaneClient) List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
+	urlPath := fmt.Sprintf("https://%s", url)
+	pipeline, err := client.cachedPipeline(urlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pager := runtime.NewPager[interface{}](runtime.PagingHandler[interface{}]{
+		More: func(current interface{}) bool {
+			return dataPlaneNextLink(current) != ""
+		},
+		Fetcher: func(ctx context.Context, current *interface{}) (interface{}, error) {
+			var request *policy.Request
+			if current == nil {
+				req, err := runtime.NewRequest(ctx, http.MethodGet, urlPath)
+				if err != nil {
+					return nil, err
+				}
+				reqQP := req.Raw().URL.Query()
+				reqQP.Set("api-version", apiVersion)
+				for key, value := range options.QueryParameters {
+					reqQP.Set(key, value)
+				}
+				req.Raw().URL.RawQuery = reqQP.Encode()
+				request = req
+			} else {
+				nextLink, err := resolveNextLink(urlPath, dataPlaneNextLink(*current))
+				if err != nil {
+					return nil, err
+				}
+				req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
+				if err != nil {
+					return nil, err
+				}
+				// some data plane services return the next link without the api-version
+				if reqQP := req.Raw().URL.Query(); reqQP.Get("api-version") == "" {
+					reqQP.Set("api-version", apiVersion)
+					req.Raw().URL.RawQuery = reqQP.Encode()
+				}
+				request = req
+			}
+			request.Raw().Header.Set("Accept", "application/json")
+			for key, value := range options.Headers {
+				request.Raw().Header.Set(key, value)
+			}
+			resp, err := pipeline.Do(request)
+			if err != nil {
+				return nil, err
+			}
+			if !runtime.HasStatusCode(resp, http.StatusOK) {
+				return nil, runtime.NewResponseError(resp)
+			}
+			var responseBody interface{}
+			if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
+				return nil, err
+			}
+			return responseBody, nil
+		},
+	})
+
+	value := make([]interface{}, 0)
+	for pageIndex := 0; pager.More(); pageIndex++ {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if pageMap, ok := page.(map[string]interface{}); ok {
+			// the items are returned in the `value` field by most services, and in the `items` field by App Configuration
+			found := false
+			for _, key := range []string{"value", "items"} {
+				if pageValue, ok := pageMap[key].([]interface{}); ok {
+					value = append(value, pageValue...)
+					found = true
+					break
+				}
+			}
+			if found {
+				continue
+			}
+		}
+
+		// if response doesn't follow the paging guideline, return the response as is
+		if pageIndex == 0 {
+			return page, nil
+		}
+		return nil, fmt.Errorf("the page %d of the list response doesn't contain the items in the `value` or `items` field", pageIndex+1)
+	}
+	return map[string]interface{}{
+		"value": value,
+	}, nil
+}
+
+// dataPlaneNextLink returns the link to the next page, data plane services use either `nextLink` or `@nextLink`.
+func dataPlaneNextLink(current interface{}) string {
+	currentMap, ok := current.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"nextLink", "@nextLink"} {
+		if nextLink, ok := currentMap[key].(string); ok && nextLink != "" {
+			return nextLink
+		}
+	}
+	return ""
+}
+
+// resolveNextLink resolves the next link against the request url, because some services return a relative link.
+func resolveNextLink(requestUrl string, nextLink string) (string, error) {
+	base, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(nextLink)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
 func (retryclient *DataPlaneClientRetryableErrors) CreateOrUpdateThenPoll(ctx context.Context, id parse.DataPlaneResourceId, body interface{}, options RequestOptions) (interface{}, error) {
 	if retryclient.backoff == nil {
 		return nil, errors.New("retry is not configured, please call WithRetry() first")
@@ -351,11 +467,13 @@ func (retryclient *DataPlaneClientRetryableErrors) CreateOrUpdateThenPoll(ctx co
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.CreateOrUpdateThenPoll(ctx, id, body, options)
 			if err != nil {
 				if isDataPlaneRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -374,7 +492,7 @@ func (retryclient *DataPlaneClientRetryableErrors) CreateOrUpdateThenPoll(ctx co
 			}
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -386,11 +504,13 @@ func (retryclient *DataPlaneClientRetryableErrors) Get(ctx context.Context, id p
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.Get(ctx, id, options)
 			if err != nil {
 				if isDataPlaneRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -409,7 +529,7 @@ func (retryclient *DataPlaneClientRetryableErrors) Get(ctx context.Context, id p
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -421,11 +541,13 @@ func (retryclient *DataPlaneClientRetryableErrors) DeleteThenPoll(ctx context.Co
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.DeleteThenPoll(ctx, id, options)
 			if err != nil {
 				if isDataPlaneRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -444,7 +566,7 @@ func (retryclient *DataPlaneClientRetryableErrors) DeleteThenPoll(ctx context.Co
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -456,11 +578,50 @@ func (retryclient *DataPlaneClientRetryableErrors) Action(ctx context.Context, r
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.Action(ctx, resourceID, action, apiVersion, method, body, options)
 			if err != nil {
 				if isDataPlaneRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
+					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
+						"err":     err,
+						"attempt": i,
+					})
+					i++
+					return data, err
+				}
+				tflog.Debug(ctx, "retryclient: PermanentError", map[string]interface{}{
+					"err":     err,
+					"attempt": i,
+				})
+				return nil, &backoff.PermanentError{Err: err}
+			}
+			tflog.Debug(ctx, "retryclient: Success", map[string]interface{}{
+				"attempt": i,
+			})
+			return data, err
+		})
+	exbo := backoff.WithContext(bkof, ctx)
+	return backoff.RetryWithData[interface{}](op, exbo)
+}
+
+func (retryclient *DataPlaneClientRetryableErrors) List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
+	if retryclient.backoff == nil {
+		return nil, errors.New("retry is not configured, please call WithRetry() first")
+	}
+	ctx = tflog.SetField(ctx, "request", "List")
+	ctx = retryclient.updateContext(ctx)
+	tflog.Debug(ctx, "retryclient: Begin")
+	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
+	op := backoff.OperationWithData[interface{}](
+		func() (interface{}, error) {
+			data, err := retryclient.client.List(ctx, url, apiVersion, options)
+			if err != nil {
+				if isDataPlaneRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -479,7 +640,7 @@ func (retryclient *DataPlaneClientRetryableErrors) Action(ctx context.Context, r
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
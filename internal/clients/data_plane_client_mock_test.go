@@ -54,6 +54,10 @@ func (m *MockDataPlaneClient) Action(ctx context.Context, resourceID string, act
 	return m.respond(ctx)
 }
 
+func (m *MockDataPlaneClient) List(ctx context.Context, url string, apiVersion string, options clients.RequestOptions) (interface{}, error) {
+	return m.respond(ctx)
+}
+
 func (m *MockDataPlaneClient) respond(ctx context.Context) (interface{}, error) {
 	select {
 	case <-ctx.Done():
@@ -3,9 +3,17 @@ package clients_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/cenkalti/backoff/v4"
@@ -124,3 +132,209 @@ func TestRetryDataPlaneClientContextDeadline(t *testing.T) {
 	_, ok := <-ctx.Done()
 	assert.False(t, ok)
 }
+
+type fakeTokenCredential struct{}
+
+func (fakeTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "fake", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestDataPlaneClientList(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/kv", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("after") {
+		case "":
+			assert.Equal(t, "1.0", r.URL.Query().Get("api-version"))
+			_, _ = w.Write([]byte(`{"items":[{"key":"a"}],"@nextLink":"/kv?after=a"}`))
+		case "a":
+			assert.Equal(t, "1.0", r.URL.Query().Get("api-version"))
+			_, _ = w.Write([]byte(`{"items":[{"key":"b"}]}`))
+		}
+	})
+	mux.HandleFunc("/relationships", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"value":[{"id":"a"}],"nextLink":"https://%s/relationships?page=2&api-version=2.0"}`, r.Host)))
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":[{"id":"b"}],"nextLink":null}`))
+	})
+	mux.HandleFunc("/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(`{"value":[{"id":"a"}],"nextLink":"/groups?page=2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"error":{"code":"Unexpected"}}`))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	client, err := clients.NewDataPlaneClient(fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud:     cloud.AzurePublic,
+			Transport: server.Client(),
+		},
+	})
+	assert.NoError(t, err)
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	resp, err := client.List(context.Background(), host+"/kv", "1.0", clients.DefaultRequestOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{"key": "a"},
+			map[string]interface{}{"key": "b"},
+		},
+	}, resp)
+
+	resp, err = client.List(context.Background(), host+"/relationships", "2.0", clients.DefaultRequestOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{"id": "a"},
+			map[string]interface{}{"id": "b"},
+		},
+	}, resp)
+
+	_, err = client.List(context.Background(), host+"/groups", "2.0", clients.DefaultRequestOptions())
+	assert.Error(t, err)
+}
+
+func TestDataPlaneClientAction(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys/mykey/rotate", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "7.4", r.URL.Query().Get("api-version"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":{"kid":"mykey/v2"}}`))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	client, err := clients.NewDataPlaneClient(fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud:     cloud.AzurePublic,
+			Transport: server.Client(),
+		},
+	})
+	assert.NoError(t, err)
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	resp, err := client.Action(context.Background(), host+"/keys/mykey", "rotate", "7.4", http.MethodPost, nil, clients.DefaultRequestOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"key": map[string]interface{}{"kid": "mykey/v2"},
+	}, resp)
+}
+
+type recordingTokenCredential struct {
+	scopes []string
+}
+
+func (c *recordingTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.scopes = append(c.scopes, options.Scopes...)
+	return azcore.AccessToken{Token: "fake", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestDataPlaneClientCustomServiceAudience(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	cloudConfig := cloud.Configuration{
+		ActiveDirectoryAuthorityHost: cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: cloud.AzurePublic.Services[cloud.ResourceManager],
+			"Custom": {
+				Endpoint: server.URL + "/",
+				Audience: "https://custom.contoso.com",
+			},
+		},
+	}
+	credential := &recordingTokenCredential{}
+	client, err := clients.NewDataPlaneClient(credential, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud:     cloudConfig,
+			Transport: server.Client(),
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Action(context.Background(), host+"/items", "", "1.0", http.MethodGet, nil, clients.DefaultRequestOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://custom.contoso.com/.default"}, credential.scopes)
+}
+
+func TestDataPlaneClientActionPollingFailures(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name        string
+		maxFailures int
+		expectErr   bool
+	}{
+		{
+			name:        "polling failure is not tolerated",
+			maxFailures: 0,
+			expectErr:   true,
+		},
+		{
+			name:        "polling failure is tolerated",
+			maxFailures: 1,
+			expectErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			pollCount := 0
+			mux := http.NewServeMux()
+			server := httptest.NewTLSServer(mux)
+			defer server.Close()
+			mux.HandleFunc("/keys/mykey/rotate", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Azure-AsyncOperation", server.URL+"/operations/1")
+				w.WriteHeader(http.StatusAccepted)
+			})
+			mux.HandleFunc("/operations/1", func(w http.ResponseWriter, r *http.Request) {
+				pollCount++
+				if pollCount == 1 {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":"Succeeded"}`))
+			})
+
+			client, err := clients.NewDataPlaneClient(fakeTokenCredential{}, &arm.ClientOptions{
+				ClientOptions: policy.ClientOptions{
+					Cloud:     cloud.AzurePublic,
+					Transport: server.Client(),
+				},
+			})
+			assert.NoError(t, err)
+			host := strings.TrimPrefix(server.URL, "https://")
+
+			options := clients.DefaultRequestOptions().WithPolling(clients.PollingOptions{
+				Interval:    time.Second,
+				MaxFailures: tc.maxFailures,
+			})
+			resp, err := client.Action(context.Background(), host+"/keys/mykey", "rotate", "7.4", http.MethodPost, nil, options)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, 1, pollCount)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, pollCount)
+			assert.Equal(t, map[string]interface{}{"status": "Succeeded"}, resp)
+		})
+	}
+}
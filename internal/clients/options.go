@@ -5,6 +5,9 @@ import "strings"
 type RequestOptions struct {
 	Headers         map[string]string
 	QueryParameters map[string]string
+	Polling         PollingOptions
+	// OperationStarted is called with the resume token once the long-running operation is accepted, before it's polled.
+	OperationStarted func(resumeToken string)
 }
 
 func DefaultRequestOptions() RequestOptions {
@@ -25,3 +28,15 @@ func NewRequestOptions(headers map[string]string, queryParameters map[string][]s
 
 	return opts
 }
+
+// WithPolling returns a copy of the options which polls the long-running operations with the given polling options.
+func (o RequestOptions) WithPolling(polling PollingOptions) RequestOptions {
+	o.Polling = polling
+	return o
+}
+
+// WithOperationStarted returns a copy of the options which calls the given function with the resume token once the long-running operation is accepted.
+func (o RequestOptions) WithOperationStarted(operationStarted func(resumeToken string)) RequestOptions {
+	o.OperationStarted = operationStarted
+	return o
+}
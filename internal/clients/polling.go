@@ -0,0 +1,100 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultPollingInterval = 10 * time.Second
+
+// PollingOptions configures how the long-running operations are polled.
+type PollingOptions struct {
+	// Interval is the duration to wait between polls. Defaults to 10 seconds.
+	Interval time.Duration
+	// IgnoreRetryAfter disables using the Retry-After header of the polling response as the duration to wait.
+	IgnoreRetryAfter bool
+	// MaxFailures is the maximum number of consecutive polling failures to tolerate before giving up.
+	MaxFailures int
+}
+
+// operationResult is the result type of the pollers, a named type is required to create the resume tokens.
+type operationResult interface{}
+
+// OperationInProgressError is returned when the polling stops before the long-running operation reaches a terminal state,
+// for example, the context is cancelled or times out. The operation can be resumed by the ResumeToken.
+type OperationInProgressError struct {
+	ResumeToken string
+	Err         error
+}
+
+func (e *OperationInProgressError) Error() string {
+	return fmt.Sprintf("the long-running operation is still in progress: %+v", e.Err)
+}
+
+func (e *OperationInProgressError) Unwrap() error {
+	return e.Err
+}
+
+// pollUntilDone polls the long-running operation until it reaches a terminal state.
+// Unlike runtime.Poller.PollUntilDone, it allows the Retry-After header to be ignored and tolerates transient polling failures.
+func pollUntilDone[T any](ctx context.Context, pt *runtime.Poller[T], options PollingOptions) (T, error) {
+	var zero T
+	interval := options.Interval
+	if interval <= 0 {
+		interval = defaultPollingInterval
+	}
+
+	failures := 0
+	for !pt.Done() {
+		delay := interval
+		resp, err := pt.Poll(ctx)
+		if err != nil && ctx.Err() != nil {
+			return zero, operationInProgressError(pt, err)
+		}
+		if err != nil {
+			failures++
+			if failures > options.MaxFailures {
+				return zero, err
+			}
+			tflog.Debug(ctx, "pollUntilDone: Polling failure is tolerated", map[string]interface{}{
+				"err":          err,
+				"failures":     failures,
+				"max_failures": options.MaxFailures,
+			})
+		} else {
+			failures = 0
+			if pt.Done() {
+				break
+			}
+			if !options.IgnoreRetryAfter {
+				if d := retryAfter(resp.Header); d > 0 {
+					delay = d
+				}
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, operationInProgressError(pt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return pt.Result(ctx)
+}
+
+func operationInProgressError[T any](pt *runtime.Poller[T], err error) error {
+	resumeToken, tokenErr := pt.ResumeToken()
+	if tokenErr != nil {
+		return err
+	}
+	return &OperationInProgressError{
+		ResumeToken: resumeToken,
+		Err:         err,
+	}
+}
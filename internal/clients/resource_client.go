@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
@@ -135,11 +134,13 @@ func (retryclient *ResourceClientRetryableErrors) CreateOrUpdate(ctx context.Con
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.CreateOrUpdate(ctx, resourceID, apiVersion, body, options)
 			if err != nil {
 				if isRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -158,7 +159,7 @@ func (retryclient *ResourceClientRetryableErrors) CreateOrUpdate(ctx context.Con
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -168,11 +169,14 @@ func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID str
 		return nil, err
 	}
 	var responseBody interface{}
-	pt, err := runtime.NewPoller[interface{}](resp, client.pl, nil)
+	pt, err := runtime.NewPoller[operationResult](resp, client.pl, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		if options.OperationStarted != nil && !pt.Done() {
+			if resumeToken, err := pt.ResumeToken(); err == nil {
+				options.OperationStarted(resumeToken)
+			}
+		}
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		if err == nil {
 			return resp, nil
 		}
@@ -186,6 +190,19 @@ func (client *ResourceClient) CreateOrUpdate(ctx context.Context, resourceID str
 	return responseBody, nil
 }
 
+// ResumeOperation resumes polling the long-running operation which was interrupted, the resume token is from the OperationInProgressError.
+func (client *ResourceClient) ResumeOperation(ctx context.Context, resumeToken string, options RequestOptions) (interface{}, error) {
+	pt, err := runtime.NewPollerFromResumeToken[operationResult](resumeToken, client.pl, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := pollUntilDone(ctx, pt, options.Polling)
+	if err != nil && client.shouldIgnorePollingError(err) {
+		return nil, nil
+	}
+	return resp, err
+}
+
 func (client *ResourceClient) createOrUpdate(ctx context.Context, resourceID string, apiVersion string, body interface{}, options RequestOptions) (*http.Response, error) {
 	req, err := client.createOrUpdateCreateRequest(ctx, resourceID, apiVersion, body, options)
 	if err != nil {
@@ -232,11 +249,13 @@ func (retryclient *ResourceClientRetryableErrors) Get(ctx context.Context, resou
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.Get(ctx, resourceID, apiVersion, options)
 			if err != nil {
 				if isRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -255,7 +274,7 @@ func (retryclient *ResourceClientRetryableErrors) Get(ctx context.Context, resou
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -310,11 +329,13 @@ func (retryclient *ResourceClientRetryableErrors) Delete(ctx context.Context, re
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.Delete(ctx, resourceID, apiVersion, options)
 			if err != nil {
 				if isRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -333,7 +354,7 @@ func (retryclient *ResourceClientRetryableErrors) Delete(ctx context.Context, re
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -343,11 +364,14 @@ func (client *ResourceClient) Delete(ctx context.Context, resourceID string, api
 		return nil, err
 	}
 	var responseBody interface{}
-	pt, err := runtime.NewPoller[interface{}](resp, client.pl, nil)
+	pt, err := runtime.NewPoller[operationResult](resp, client.pl, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		if options.OperationStarted != nil && !pt.Done() {
+			if resumeToken, err := pt.ResumeToken(); err == nil {
+				options.OperationStarted(resumeToken)
+			}
+		}
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		if err == nil {
 			return resp, nil
 		}
@@ -407,11 +431,13 @@ func (retryclient *ResourceClientRetryableErrors) Action(ctx context.Context, re
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.Action(ctx, resourceID, action, apiVersion, method, body, options)
 			if err != nil {
 				if isRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -430,7 +456,7 @@ func (retryclient *ResourceClientRetryableErrors) Action(ctx context.Context, re
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -440,11 +466,14 @@ func (client *ResourceClient) Action(ctx context.Context, resourceID string, act
 		return nil, err
 	}
 	var responseBody interface{}
-	pt, err := runtime.NewPoller[interface{}](resp, client.pl, nil)
+	pt, err := runtime.NewPoller[operationResult](resp, client.pl, nil)
 	if err == nil {
-		resp, err := pt.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
-			Frequency: 10 * time.Second,
-		})
+		if options.OperationStarted != nil && !pt.Done() {
+			if resumeToken, err := pt.ResumeToken(); err == nil {
+				options.OperationStarted(resumeToken)
+			}
+		}
+		resp, err := pollUntilDone(ctx, pt, options.Polling)
 		if err == nil {
 			return resp, nil
 		}
@@ -522,11 +551,13 @@ func (retryclient *ResourceClientRetryableErrors) List(ctx context.Context, url
 	ctx = retryclient.updateContext(ctx)
 	tflog.Debug(ctx, "retryclient: Begin")
 	i := 0
+	bkof := newRetryAfterBackOff(retryclient.backoff)
 	op := backoff.OperationWithData[interface{}](
 		func() (interface{}, error) {
 			data, err := retryclient.client.List(ctx, url, apiVersion, options)
 			if err != nil {
 				if isRetryable(ctx, *retryclient, data, err) {
+					bkof.setRetryAfter(err)
 					tflog.Debug(ctx, "retryclient: Retry attempt", map[string]interface{}{
 						"err":     err,
 						"attempt": i,
@@ -545,7 +576,7 @@ func (retryclient *ResourceClientRetryableErrors) List(ctx context.Context, url
 			})
 			return data, err
 		})
-	exbo := backoff.WithContext(retryclient.backoff, ctx)
+	exbo := backoff.WithContext(bkof, ctx)
 	return backoff.RetryWithData[interface{}](op, exbo)
 }
 
@@ -661,6 +692,11 @@ func (client *ResourceClient) shouldIgnorePollingError(err error) bool {
 }
 
 func isRetryable(ctx context.Context, retryclient ResourceClientRetryableErrors, data interface{}, err error) bool {
+	// the interrupted long-running operation must not be re-issued, it should be resumed instead
+	var inProgressErr *OperationInProgressError
+	if errors.As(err, &inProgressErr) {
+		return false
+	}
 	for _, e := range retryclient.errors {
 		if e.MatchString(err.Error()) {
 			tflog.Debug(ctx, "isRetryable: Error is retryable by regex", map[string]interface{}{
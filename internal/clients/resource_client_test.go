@@ -3,9 +3,16 @@ package clients_test
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
@@ -101,6 +108,34 @@ func TestRetryClientMultiRegexpNoMatchWithPermError(t *testing.T) {
 	assert.Equal(t, 3, mock.RequestCount())
 }
 
+// TestRetryClientStatusCodeRetryAfter tests that the error is retried by its status code,
+// and the next request waits for the duration specified in the Retry-After header instead of the shorter backoff interval.
+func TestRetryClientStatusCodeRetryAfter(t *testing.T) {
+	t.Parallel()
+	req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com/subscriptions", nil)
+	retryErr := &azcore.ResponseError{
+		StatusCode: http.StatusTooManyRequests,
+		RawResponse: &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"2"}},
+			Request:    req,
+		},
+	}
+	mock := NewMockResourceClient(t, nil, nil, 1, retryErr)
+	bkof := backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(100*time.Millisecond),
+		backoff.WithMaxInterval(1*time.Second),
+		backoff.WithMultiplier(1.5),
+		backoff.WithRandomizationFactor(0.0),
+	)
+	retryClient := clients.NewResourceClientRetryableErrors(mock, bkof, nil, []int{http.StatusTooManyRequests}, nil)
+	_, err := retryClient.Get(context.Background(), "", "", clients.DefaultRequestOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mock.RequestCount())
+	assert.Len(t, mock.requestTimes, 2)
+	assert.InDelta(t, 2e+09, int(mock.requestTimes[1].Sub(mock.requestTimes[0]).Nanoseconds()), 1e+08)
+}
+
 func TestRetryClientContextDeadline(t *testing.T) {
 	t.Parallel()
 	mock := NewMockResourceClient(t, nil, nil, 3, errors.New("retry error"))
@@ -123,3 +158,67 @@ func TestRetryClientContextDeadline(t *testing.T) {
 	_, ok := <-ctx.Done()
 	assert.False(t, ok)
 }
+
+// TestResourceClientResumeOperation tests that the interrupted long-running operation returns the resume token,
+// and the operation can be resumed with it instead of sending the PUT request again.
+func TestResourceClientResumeOperation(t *testing.T) {
+	t.Parallel()
+	var putCount atomic.Int32
+	var done atomic.Bool
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			putCount.Add(1)
+			w.Header().Set("Azure-AsyncOperation", server.URL+"/operations/1")
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{"name":"rg"}`))
+	})
+	mux.HandleFunc("/operations/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if done.Load() {
+			_, _ = w.Write([]byte(`{"status":"Succeeded"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"InProgress"}`))
+	})
+
+	client, err := clients.NewResourceClient(fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Endpoint: server.URL,
+						Audience: "https://management.core.windows.net/",
+					},
+				},
+			},
+			Transport: server.Client(),
+		},
+		DisableRPRegistration: true,
+	})
+	assert.NoError(t, err)
+
+	var startedResumeToken string
+	options := clients.DefaultRequestOptions().WithPolling(clients.PollingOptions{Interval: time.Second}).WithOperationStarted(func(resumeToken string) {
+		startedResumeToken = resumeToken
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	_, err = client.CreateOrUpdate(ctx, "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg", "2021-04-01", map[string]interface{}{}, options)
+	var inProgressErr *clients.OperationInProgressError
+	assert.ErrorAs(t, err, &inProgressErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotEmpty(t, inProgressErr.ResumeToken)
+	assert.NotEmpty(t, startedResumeToken)
+
+	done.Store(true)
+	resp, err := client.ResumeOperation(context.Background(), inProgressErr.ResumeToken, options)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "rg"}, resp)
+	assert.Equal(t, int32(1), putCount.Load())
+}
@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// retryAfterBackOff is a wrapper around backoff.BackOff that honors the retry-after hint returned by the server.
+// The next backoff interval is the larger one of the wrapped backoff's interval and the retry-after hint.
+type retryAfterBackOff struct {
+	backoff.BackOff
+	retryAfter time.Duration
+}
+
+func newRetryAfterBackOff(b backoff.BackOff) *retryAfterBackOff {
+	return &retryAfterBackOff{BackOff: b}
+}
+
+// setRetryAfter records the retry-after hint of the error, it will be used to compute the next backoff interval.
+func (b *retryAfterBackOff) setRetryAfter(err error) {
+	b.retryAfter = retryAfterFromError(err)
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next != backoff.Stop && b.retryAfter > next {
+		next = b.retryAfter
+	}
+	b.retryAfter = 0
+	return next
+}
+
+func (b *retryAfterBackOff) Reset() {
+	b.retryAfter = 0
+	b.BackOff.Reset()
+}
+
+// retryAfterFromError returns the retry-after hint in the response of the error, it returns 0 if there's no hint.
+// The `x-ms-retry-after-ms` and `retry-after-ms` headers are preferred over the `Retry-After` header, which is either a number of seconds or an HTTP date.
+func retryAfterFromError(err error) time.Duration {
+	var respErr *azcore.ResponseError
+	if !errors.As(err, &respErr) || respErr.RawResponse == nil {
+		return 0
+	}
+	return retryAfter(respErr.RawResponse.Header)
+}
+
+func retryAfter(header http.Header) time.Duration {
+	for _, key := range []string{"x-ms-retry-after-ms", "retry-after-ms"} {
+		if v := header.Get(key); v != "" {
+			if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+	return 0
+}
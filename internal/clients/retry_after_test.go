@@ -0,0 +1,93 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	testcases := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{
+			name:   "no header",
+			header: http.Header{},
+			want:   0,
+		},
+		{
+			name:   "retry-after in seconds",
+			header: http.Header{"Retry-After": []string{"5"}},
+			want:   5 * time.Second,
+		},
+		{
+			name:   "x-ms-retry-after-ms",
+			header: http.Header{"X-Ms-Retry-After-Ms": []string{"1500"}},
+			want:   1500 * time.Millisecond,
+		},
+		{
+			name:   "x-ms-retry-after-ms is preferred",
+			header: http.Header{"X-Ms-Retry-After-Ms": []string{"200"}, "Retry-After": []string{"5"}},
+			want:   200 * time.Millisecond,
+		},
+		{
+			name:   "invalid value",
+			header: http.Header{"Retry-After": []string{"invalid"}},
+			want:   0,
+		},
+		{
+			name:   "date in the past",
+			header: http.Header{"Retry-After": []string{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}},
+			want:   0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, retryAfter(tc.header))
+		})
+	}
+
+	d := retryAfter(http.Header{"Retry-After": []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}})
+	assert.InDelta(t, float64(time.Minute), float64(d), float64(2*time.Second))
+}
+
+func TestRetryAfterBackOff(t *testing.T) {
+	bkof := newRetryAfterBackOff(backoff.NewConstantBackOff(time.Second))
+
+	// the retry-after hint is used when it's longer than the wrapped backoff's interval
+	bkof.setRetryAfter(&azcore.ResponseError{
+		StatusCode:  http.StatusTooManyRequests,
+		RawResponse: &http.Response{Header: http.Header{"Retry-After": []string{"3"}}},
+	})
+	assert.Equal(t, 3*time.Second, bkof.NextBackOff())
+
+	// the hint only applies to the next interval
+	assert.Equal(t, time.Second, bkof.NextBackOff())
+
+	// the wrapped backoff's interval is used when it's longer than the retry-after hint
+	bkof.setRetryAfter(&azcore.ResponseError{
+		StatusCode:  http.StatusTooManyRequests,
+		RawResponse: &http.Response{Header: http.Header{"X-Ms-Retry-After-Ms": []string{"100"}}},
+	})
+	assert.Equal(t, time.Second, bkof.NextBackOff())
+
+	// errors without a response don't have a hint
+	bkof.setRetryAfter(errors.New("retry error"))
+	assert.Equal(t, time.Second, bkof.NextBackOff())
+
+	// the wrapped backoff's stop is respected
+	stop := newRetryAfterBackOff(&backoff.StopBackOff{})
+	stop.setRetryAfter(&azcore.ResponseError{
+		StatusCode:  http.StatusTooManyRequests,
+		RawResponse: &http.Response{Header: http.Header{"Retry-After": []string{"3"}}},
+	})
+	assert.Equal(t, backoff.Stop, stop.NextBackOff())
+}
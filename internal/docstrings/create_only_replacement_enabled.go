@@ -0,0 +1,10 @@
+package docstrings
+
+const (
+	createOnlyReplacementEnabledStr = `Whether replace the resource when the create-only properties in the %sbody%s are changed. The create-only properties are the properties marked as deploy-time constants in the embedded schema, which can't be updated after the resource is created. Defaults to %strue%s.`
+)
+
+// CreateOnlyReplacementEnabled returns the docstring for the create_only_replacement_enabled schema attribute.
+func CreateOnlyReplacementEnabled() string {
+	return addBackquotes(createOnlyReplacementEnabledStr)
+}
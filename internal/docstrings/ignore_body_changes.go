@@ -0,0 +1,10 @@
+package docstrings
+
+const (
+	ignoreBodyChangesStr = `A list of paths in the %sbody%s whose changes are ignored, e.g. %sproperties.networkAcls.ipRules%s or %sproperties.rules[0].enabled%s, and %s[*]%s matches all the items in an array. It's useful when the properties are managed outside of Terraform, e.g. by Azure Policy. The values at these paths are kept the same as the state when reading the resource, the changes at these paths in the configuration are not planned, and the current values of the resource at these paths are sent instead of the configured values when updating the resource.`
+)
+
+// IgnoreBodyChanges returns the docstring for ignore_body_changes schema attribute.
+func IgnoreBodyChanges() string {
+	return addBackquotes(ignoreBodyChangesStr)
+}
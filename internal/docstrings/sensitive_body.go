@@ -0,0 +1,10 @@
+package docstrings
+
+const (
+	sensitiveBodyStr = `A dynamic attribute that contains the sensitive properties of the request body, e.g. passwords or connection strings. It's merged into the %sbody%s to construct the request body, and its value is marked as sensitive so that it's not displayed in the plan output and logs.`
+)
+
+// SensitiveBody returns the docstring for the sensitive_body schema attribute.
+func SensitiveBody() string {
+	return addBackquotes(sensitiveBodyStr)
+}
@@ -0,0 +1,10 @@
+package docstrings
+
+const (
+	unorderedArraysStr = `A list of paths to the arrays in the %sbody%s whose order doesn't matter, e.g. %sproperties.networkAcls.ipRules%s or %sproperties.cors.allowedOrigins%s, and %s[*]%s matches all the items in an array. When reading the resource, the configured order is kept if the array contains the same items as the configuration regardless of the order, and the changes of the order in the configuration are not planned.`
+)
+
+// UnorderedArrays returns the docstring for unordered_arrays schema attribute.
+func UnorderedArrays() string {
+	return addBackquotes(unorderedArraysStr)
+}
@@ -1,19 +1,32 @@
 package features
 
+import (
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
+)
+
 type UserFeatures struct {
-	DefaultTags          map[string]string
-	DefaultLocation      string
-	DefaultNaming        string
-	EnablePreflight      bool
-	DisableDefaultOutput bool
+	DefaultTags                map[string]string
+	DefaultLocation            string
+	DefaultNaming              string
+	EnablePreflight            bool
+	EnableWhatIf               bool
+	DisableDefaultOutput       bool
+	ApiVersionWarningsAsErrors bool
+	DefaultRetry               retry.RetryValue
+	DefaultPolling             polling.PollingValue
 }
 
 func Default() UserFeatures {
 	return UserFeatures{
-		DefaultTags:          nil,
-		DefaultLocation:      "",
-		DefaultNaming:        "",
-		EnablePreflight:      false,
-		DisableDefaultOutput: false,
+		DefaultTags:                nil,
+		DefaultLocation:            "",
+		DefaultNaming:              "",
+		EnablePreflight:            false,
+		EnableWhatIf:               false,
+		DisableDefaultOutput:       false,
+		ApiVersionWarningsAsErrors: false,
+		DefaultRetry:               retry.NewRetryValueNull(),
+		DefaultPolling:             polling.PollingValue{},
 	}
 }
@@ -0,0 +1,148 @@
+package polling
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	intervalSecondsAttributeName = "interval_seconds"
+	honorRetryAfterAttributeName = "honor_retry_after"
+	maxPollFailuresAttributeName = "max_poll_failures"
+)
+
+const (
+	intervalSecondsDescription = "The number of seconds to wait between polls of the long-running operation. Default is `10`."
+	honorRetryAfterDescription = "Whether the `Retry-After` header of the polling response overrides `interval_seconds`. Default is `true`."
+	maxPollFailuresDescription = "The maximum number of consecutive polling failures to tolerate before giving up. Default is `0`."
+)
+
+// PollingValue is the model of the `polling` block, the null fields fall back to the defaults.
+type PollingValue struct {
+	IntervalSeconds types.Int64 `tfsdk:"interval_seconds"`
+	HonorRetryAfter types.Bool  `tfsdk:"honor_retry_after"`
+	MaxPollFailures types.Int64 `tfsdk:"max_poll_failures"`
+}
+
+func AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		intervalSecondsAttributeName: types.Int64Type,
+		honorRetryAfterAttributeName: types.BoolType,
+		maxPollFailuresAttributeName: types.Int64Type,
+	}
+}
+
+func SingleNestedAttribute() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The polling block configures how the long-running operations are polled. When it's not specified, the `default_polling` block in the provider configuration is used. It supports the following arguments:",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			intervalSecondsAttributeName: schema.Int64Attribute{
+				MarkdownDescription: intervalSecondsDescription,
+				Optional:            true,
+				Validators:          intervalSecondsValidators(),
+			},
+
+			honorRetryAfterAttributeName: schema.BoolAttribute{
+				MarkdownDescription: honorRetryAfterDescription,
+				Optional:            true,
+			},
+
+			maxPollFailuresAttributeName: schema.Int64Attribute{
+				MarkdownDescription: maxPollFailuresDescription,
+				Optional:            true,
+				Validators:          maxPollFailuresValidators(),
+			},
+		},
+	}
+}
+
+// ProviderSingleNestedAttribute returns the provider level `default_polling` block.
+func ProviderSingleNestedAttribute() providerschema.Attribute {
+	return providerschema.SingleNestedAttribute{
+		MarkdownDescription: "The default polling block which is used by all resources that don't specify the `polling` block. It supports the following arguments:",
+		Optional:            true,
+		Attributes: map[string]providerschema.Attribute{
+			intervalSecondsAttributeName: providerschema.Int64Attribute{
+				MarkdownDescription: intervalSecondsDescription,
+				Optional:            true,
+				Validators:          intervalSecondsValidators(),
+			},
+
+			honorRetryAfterAttributeName: providerschema.BoolAttribute{
+				MarkdownDescription: honorRetryAfterDescription,
+				Optional:            true,
+			},
+
+			maxPollFailuresAttributeName: providerschema.Int64Attribute{
+				MarkdownDescription: maxPollFailuresDescription,
+				Optional:            true,
+				Validators:          maxPollFailuresValidators(),
+			},
+		},
+	}
+}
+
+func intervalSecondsValidators() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(1),
+		int64validator.AtMost(3600),
+	}
+}
+
+func maxPollFailuresValidators() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(0),
+		int64validator.AtMost(100),
+	}
+}
+
+// FromObject converts the `polling` block to a PollingValue, a null or unknown block results in a value whose fields are all null.
+func FromObject(ctx context.Context, input types.Object) (PollingValue, diag.Diagnostics) {
+	var v PollingValue
+	if input.IsNull() || input.IsUnknown() {
+		return v, nil
+	}
+	diags := input.As(ctx, &v, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+	return v, diags
+}
+
+// OrDefault returns a copy of the value whose null fields are replaced by the fields of the given default value.
+func (v PollingValue) OrDefault(defaultValue PollingValue) PollingValue {
+	if v.IntervalSeconds.IsNull() || v.IntervalSeconds.IsUnknown() {
+		v.IntervalSeconds = defaultValue.IntervalSeconds
+	}
+	if v.HonorRetryAfter.IsNull() || v.HonorRetryAfter.IsUnknown() {
+		v.HonorRetryAfter = defaultValue.HonorRetryAfter
+	}
+	if v.MaxPollFailures.IsNull() || v.MaxPollFailures.IsUnknown() {
+		v.MaxPollFailures = defaultValue.MaxPollFailures
+	}
+	return v
+}
+
+// GetInterval returns the duration to wait between polls, it returns 0 if it's not specified.
+func (v PollingValue) GetInterval() time.Duration {
+	return time.Duration(v.IntervalSeconds.ValueInt64()) * time.Second
+}
+
+// GetHonorRetryAfter returns whether the `Retry-After` header overrides the interval, it returns true if it's not specified.
+func (v PollingValue) GetHonorRetryAfter() bool {
+	if v.HonorRetryAfter.IsNull() || v.HonorRetryAfter.IsUnknown() {
+		return true
+	}
+	return v.HonorRetryAfter.ValueBool()
+}
+
+func (v PollingValue) GetMaxPollFailures() int {
+	return int(v.MaxPollFailures.ValueInt64())
+}
@@ -41,4 +41,47 @@ func init() {
 		Audience: "https://dev.azuresynapse.net",
 		Endpoint: "https://dev.azuresynapse.net",
 	}
+
+	// DeviceUpdate and IoTCentral are only available in the public cloud.
+	cloud.AzureGovernment.Services[AppConfiguration] = cloud.ServiceConfiguration{
+		Audience: "https://azconfig.azure.us",
+		Endpoint: "https://azconfig.azure.us",
+	}
+	cloud.AzureGovernment.Services[DigitalTwins] = cloud.ServiceConfiguration{
+		Audience: "https://digitaltwins.azure.us",
+		Endpoint: "https://digitaltwins.azure.us",
+	}
+	cloud.AzureGovernment.Services[KeyVault] = cloud.ServiceConfiguration{
+		Audience: "https://vault.usgovcloudapi.net",
+		Endpoint: "https://vault.usgovcloudapi.net",
+	}
+	cloud.AzureGovernment.Services[Purview] = cloud.ServiceConfiguration{
+		Audience: "https://purview.azure.us",
+		Endpoint: "https://purview.azure.us",
+	}
+	cloud.AzureGovernment.Services[Synapse] = cloud.ServiceConfiguration{
+		Audience: "https://dev.azuresynapse.usgovcloudapi.net",
+		Endpoint: "https://dev.azuresynapse.usgovcloudapi.net",
+	}
+
+	cloud.AzureChina.Services[AppConfiguration] = cloud.ServiceConfiguration{
+		Audience: "https://azconfig.azure.cn",
+		Endpoint: "https://azconfig.azure.cn",
+	}
+	cloud.AzureChina.Services[DigitalTwins] = cloud.ServiceConfiguration{
+		Audience: "https://digitaltwins.azure.cn",
+		Endpoint: "https://digitaltwins.azure.cn",
+	}
+	cloud.AzureChina.Services[KeyVault] = cloud.ServiceConfiguration{
+		Audience: "https://vault.azure.cn",
+		Endpoint: "https://vault.azure.cn",
+	}
+	cloud.AzureChina.Services[Purview] = cloud.ServiceConfiguration{
+		Audience: "https://purview.azure.cn",
+		Endpoint: "https://purview.azure.cn",
+	}
+	cloud.AzureChina.Services[Synapse] = cloud.ServiceConfiguration{
+		Audience: "https://dev.azuresynapse.azure.cn",
+		Endpoint: "https://dev.azuresynapse.azure.cn",
+	}
 }
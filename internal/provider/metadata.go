@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+const metadataApiVersion = "2022-09-01"
+
+type metadataAuthentication struct {
+	LoginEndpoint string   `json:"loginEndpoint"`
+	Audiences     []string `json:"audiences"`
+}
+
+type metadataSuffixes struct {
+	KeyVaultDns      string `json:"keyVaultDns"`
+	SynapseAnalytics string `json:"synapseAnalytics"`
+}
+
+type metadataEndpoints struct {
+	Name                       string                 `json:"name"`
+	ResourceManager            string                 `json:"resourceManager"`
+	Authentication             metadataAuthentication `json:"authentication"`
+	Suffixes                   metadataSuffixes       `json:"suffixes"`
+	SynapseAnalyticsResourceId string                 `json:"synapseAnalyticsResourceId"`
+}
+
+// cloudConfigurationFromMetadataHost builds the cloud configuration from the ARM metadata endpoint `https://<metadataHost>/metadata/endpoints`.
+// The endpoint returns a list of clouds in the newer api-versions, the one whose resource manager is hosted on the metadata host is used.
+// Azure Stack Hub returns a single object, whose resource manager is the metadata host itself.
+func cloudConfigurationFromMetadataHost(ctx context.Context, client *http.Client, metadataHost string) (cloud.Configuration, error) {
+	metadataHost = strings.TrimSuffix(strings.TrimPrefix(metadataHost, "https://"), "/")
+	metadataUrl := fmt.Sprintf("https://%s/metadata/endpoints?api-version=%s", metadataHost, metadataApiVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataUrl, nil)
+	if err != nil {
+		return cloud.Configuration{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("retrieving metadata from %q: %+v", metadataUrl, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return cloud.Configuration{}, fmt.Errorf("reading metadata from %q: %+v", metadataUrl, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return cloud.Configuration{}, fmt.Errorf("retrieving metadata from %q: unexpected status %d: %s", metadataUrl, resp.StatusCode, string(data))
+	}
+
+	var environments []metadataEndpoints
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &environments); err != nil {
+			return cloud.Configuration{}, fmt.Errorf("unmarshalling metadata from %q: %+v", metadataUrl, err)
+		}
+	} else {
+		var environment metadataEndpoints
+		if err := json.Unmarshal(data, &environment); err != nil {
+			return cloud.Configuration{}, fmt.Errorf("unmarshalling metadata from %q: %+v", metadataUrl, err)
+		}
+		if environment.ResourceManager == "" {
+			environment.ResourceManager = fmt.Sprintf("https://%s/", metadataHost)
+		}
+		environments = append(environments, environment)
+	}
+
+	var environment *metadataEndpoints
+	for i := range environments {
+		if u, err := url.Parse(environments[i].ResourceManager); err == nil && strings.EqualFold(u.Host, metadataHost) {
+			environment = &environments[i]
+			break
+		}
+	}
+	if environment == nil {
+		if len(environments) != 1 {
+			return cloud.Configuration{}, fmt.Errorf("no cloud whose resource manager is hosted on %q is found in the metadata", metadataHost)
+		}
+		environment = &environments[0]
+	}
+
+	return environment.cloudConfiguration()
+}
+
+func (e metadataEndpoints) cloudConfiguration() (cloud.Configuration, error) {
+	if e.Authentication.LoginEndpoint == "" {
+		return cloud.Configuration{}, fmt.Errorf("the metadata doesn't contain the login endpoint")
+	}
+	if len(e.Authentication.Audiences) == 0 {
+		return cloud.Configuration{}, fmt.Errorf("the metadata doesn't contain the resource manager audience")
+	}
+
+	config := cloud.Configuration{
+		ActiveDirectoryAuthorityHost: strings.TrimSuffix(e.Authentication.LoginEndpoint, "/") + "/",
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Audience: e.Authentication.Audiences[0],
+				Endpoint: e.ResourceManager,
+			},
+		},
+	}
+	if v := strings.TrimPrefix(e.Suffixes.KeyVaultDns, "."); v != "" {
+		config.Services[KeyVault] = cloud.ServiceConfiguration{
+			Audience: "https://" + v,
+			Endpoint: "https://" + v,
+		}
+	}
+	if v := strings.TrimPrefix(e.Suffixes.SynapseAnalytics, "."); v != "" {
+		audience := e.SynapseAnalyticsResourceId
+		if audience == "" {
+			audience = "https://" + v
+		}
+		config.Services[Synapse] = cloud.ServiceConfiguration{
+			Audience: audience,
+			Endpoint: "https://" + v,
+		}
+	}
+
+	// the metadata only exposes the Key Vault and Synapse endpoints, the other data plane services of the well-known clouds
+	// are added from their built-in configurations, they must be configured in the provider `endpoint` block for other clouds.
+	for _, known := range []cloud.Configuration{cloud.AzurePublic, cloud.AzureGovernment, cloud.AzureChina} {
+		if !strings.EqualFold(strings.TrimSuffix(known.Services[cloud.ResourceManager].Endpoint, "/"), strings.TrimSuffix(e.ResourceManager, "/")) {
+			continue
+		}
+		for name, service := range known.Services {
+			if _, ok := config.Services[name]; !ok {
+				config.Services[name] = service
+			}
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func Test_cloudConfigurationFromMetadataHost(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Response func(host string) string
+		Expected func(host string) cloud.Configuration
+		Error    bool
+	}{
+		{
+			Name: "list of clouds",
+			Response: func(host string) string {
+				return fmt.Sprintf(`[
+  {
+    "name": "AzureCloud",
+    "resourceManager": "https://management.azure.com/",
+    "authentication": {"loginEndpoint": "https://login.microsoftonline.com", "audiences": ["https://management.core.windows.net/"]}
+  },
+  {
+    "name": "AirGapped",
+    "resourceManager": "https://%[1]s/",
+    "authentication": {"loginEndpoint": "https://login.contoso.com/", "audiences": ["https://management.contoso.com/"]},
+    "suffixes": {"keyVaultDns": ".vault.contoso.com", "synapseAnalytics": "dev.synapse.contoso.com"},
+    "synapseAnalyticsResourceId": "https://dev.synapse.contoso.com"
+  }
+]`, host)
+			},
+			Expected: func(host string) cloud.Configuration {
+				return cloud.Configuration{
+					ActiveDirectoryAuthorityHost: "https://login.contoso.com/",
+					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+						cloud.ResourceManager: {
+							Audience: "https://management.contoso.com/",
+							Endpoint: fmt.Sprintf("https://%s/", host),
+						},
+						KeyVault: {
+							Audience: "https://vault.contoso.com",
+							Endpoint: "https://vault.contoso.com",
+						},
+						Synapse: {
+							Audience: "https://dev.synapse.contoso.com",
+							Endpoint: "https://dev.synapse.contoso.com",
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "azure stack hub",
+			Response: func(host string) string {
+				return `{
+  "galleryEndpoint": "https://adminportal.local.azurestack.external:30015/",
+  "graphEndpoint": "https://graph.local.azurestack.external/",
+  "authentication": {"loginEndpoint": "https://adfs.local.azurestack.external/adfs", "audiences": ["https://management.adfs.azurestack.local/0000"]}
+}`
+			},
+			Expected: func(host string) cloud.Configuration {
+				return cloud.Configuration{
+					ActiveDirectoryAuthorityHost: "https://adfs.local.azurestack.external/adfs/",
+					Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+						cloud.ResourceManager: {
+							Audience: "https://management.adfs.azurestack.local/0000",
+							Endpoint: fmt.Sprintf("https://%s/", host),
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "no matching cloud",
+			Response: func(host string) string {
+				return `[
+  {"name": "AzureCloud", "resourceManager": "https://management.azure.com/", "authentication": {"loginEndpoint": "https://login.microsoftonline.com", "audiences": ["https://management.core.windows.net/"]}},
+  {"name": "AzureChinaCloud", "resourceManager": "https://management.chinacloudapi.cn/", "authentication": {"loginEndpoint": "https://login.chinacloudapi.cn", "audiences": ["https://management.core.chinacloudapi.cn/"]}}
+]`
+			},
+			Error: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var response string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/metadata/endpoints" || r.URL.Query().Get("api-version") == "" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+			}))
+			defer server.Close()
+			host := strings.TrimPrefix(server.URL, "https://")
+			response = tc.Response(host)
+
+			actual, err := cloudConfigurationFromMetadataHost(context.Background(), server.Client(), host)
+			if tc.Error {
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			expected := tc.Expected(host)
+			if actual.ActiveDirectoryAuthorityHost != expected.ActiveDirectoryAuthorityHost {
+				t.Fatalf("expected authority host %q but got %q", expected.ActiveDirectoryAuthorityHost, actual.ActiveDirectoryAuthorityHost)
+			}
+			if len(actual.Services) != len(expected.Services) {
+				t.Fatalf("expected %d services but got %d: %+v", len(expected.Services), len(actual.Services), actual.Services)
+			}
+			for name, service := range expected.Services {
+				if actual.Services[name] != service {
+					t.Fatalf("expected service %s to be %+v but got %+v", name, service, actual.Services[name])
+				}
+			}
+		})
+	}
+}
+
+func Test_metadataEndpointsCloudConfiguration(t *testing.T) {
+	testcases := []struct {
+		Name        string
+		Endpoints   metadataEndpoints
+		Expected    map[cloud.ServiceName]cloud.ServiceConfiguration
+		NotExpected []cloud.ServiceName
+	}{
+		{
+			Name: "public cloud",
+			Endpoints: metadataEndpoints{
+				ResourceManager: "https://management.azure.com/",
+				Authentication:  metadataAuthentication{LoginEndpoint: "https://login.microsoftonline.com", Audiences: []string{"https://management.core.windows.net/"}},
+				Suffixes:        metadataSuffixes{KeyVaultDns: "vault.azure.net", SynapseAnalytics: "dev.azuresynapse.net"},
+			},
+			Expected: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				KeyVault:         {Audience: "https://vault.azure.net", Endpoint: "https://vault.azure.net"},
+				Synapse:          {Audience: "https://dev.azuresynapse.net", Endpoint: "https://dev.azuresynapse.net"},
+				AppConfiguration: cloud.AzurePublic.Services[AppConfiguration],
+				DeviceUpdate:     cloud.AzurePublic.Services[DeviceUpdate],
+				DigitalTwins:     cloud.AzurePublic.Services[DigitalTwins],
+				IoTCentral:       cloud.AzurePublic.Services[IoTCentral],
+				Purview:          cloud.AzurePublic.Services[Purview],
+			},
+		},
+		{
+			Name: "china cloud",
+			Endpoints: metadataEndpoints{
+				ResourceManager: "https://management.chinacloudapi.cn",
+				Authentication:  metadataAuthentication{LoginEndpoint: "https://login.chinacloudapi.cn", Audiences: []string{"https://management.core.chinacloudapi.cn/"}},
+				Suffixes:        metadataSuffixes{KeyVaultDns: ".vault.azure.cn"},
+			},
+			Expected: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				KeyVault:         {Audience: "https://vault.azure.cn", Endpoint: "https://vault.azure.cn"},
+				Synapse:          cloud.AzureChina.Services[Synapse],
+				AppConfiguration: cloud.AzureChina.Services[AppConfiguration],
+				DigitalTwins:     cloud.AzureChina.Services[DigitalTwins],
+				Purview:          cloud.AzureChina.Services[Purview],
+			},
+			NotExpected: []cloud.ServiceName{DeviceUpdate, IoTCentral},
+		},
+		{
+			Name: "custom cloud",
+			Endpoints: metadataEndpoints{
+				ResourceManager: "https://management.contoso.com/",
+				Authentication:  metadataAuthentication{LoginEndpoint: "https://login.contoso.com", Audiences: []string{"https://management.contoso.com/"}},
+				Suffixes:        metadataSuffixes{KeyVaultDns: ".vault.contoso.com"},
+			},
+			Expected: map[cloud.ServiceName]cloud.ServiceConfiguration{
+				KeyVault: {Audience: "https://vault.contoso.com", Endpoint: "https://vault.contoso.com"},
+			},
+			NotExpected: []cloud.ServiceName{AppConfiguration, DeviceUpdate, DigitalTwins, IoTCentral, Purview, Synapse},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := tc.Endpoints.cloudConfiguration()
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			for name, service := range tc.Expected {
+				if actual.Services[name] != service {
+					t.Fatalf("expected service %s to be %+v but got %+v", name, service, actual.Services[name])
+				}
+			}
+			for _, name := range tc.NotExpected {
+				if service, ok := actual.Services[name]; ok {
+					t.Fatalf("expected service %s to be absent but got %+v", name, service)
+				}
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
@@ -16,6 +18,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services"
 	"github.com/Azure/terraform-provider-azapi/internal/services/functions"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
@@ -46,37 +50,42 @@ type Provider struct {
 }
 
 type providerData struct {
-	SubscriptionID               types.String `tfsdk:"subscription_id"`
-	ClientID                     types.String `tfsdk:"client_id"`
-	ClientIDFilePath             types.String `tfsdk:"client_id_file_path"`
-	TenantID                     types.String `tfsdk:"tenant_id"`
-	AuxiliaryTenantIDs           types.List   `tfsdk:"auxiliary_tenant_ids"`
-	Endpoint                     types.List   `tfsdk:"endpoint"`
-	Environment                  types.String `tfsdk:"environment"`
-	ClientCertificate            types.String `tfsdk:"client_certificate"`
-	ClientCertificatePath        types.String `tfsdk:"client_certificate_path"`
-	ClientCertificatePassword    types.String `tfsdk:"client_certificate_password"`
-	ClientSecret                 types.String `tfsdk:"client_secret"`
-	ClientSecretFilePath         types.String `tfsdk:"client_secret_file_path"`
-	SkipProviderRegistration     types.Bool   `tfsdk:"skip_provider_registration"`
-	OIDCRequestToken             types.String `tfsdk:"oidc_request_token"`
-	OIDCRequestURL               types.String `tfsdk:"oidc_request_url"`
-	OIDCToken                    types.String `tfsdk:"oidc_token"`
-	OIDCTokenFilePath            types.String `tfsdk:"oidc_token_file_path"`
-	OIDCAzureServiceConnectionID types.String `tfsdk:"oidc_azure_service_connection_id"`
-	UseOIDC                      types.Bool   `tfsdk:"use_oidc"`
-	UseCLI                       types.Bool   `tfsdk:"use_cli"`
-	UseMSI                       types.Bool   `tfsdk:"use_msi"`
-	UseAKSWorkloadIdentity       types.Bool   `tfsdk:"use_aks_workload_identity"`
-	PartnerID                    types.String `tfsdk:"partner_id"`
-	CustomCorrelationRequestID   types.String `tfsdk:"custom_correlation_request_id"`
-	DisableCorrelationRequestID  types.Bool   `tfsdk:"disable_correlation_request_id"`
-	DisableTerraformPartnerID    types.Bool   `tfsdk:"disable_terraform_partner_id"`
-	DefaultName                  types.String `tfsdk:"default_name"`
-	DefaultLocation              types.String `tfsdk:"default_location"`
-	DefaultTags                  types.Map    `tfsdk:"default_tags"`
-	EnablePreflight              types.Bool   `tfsdk:"enable_preflight"`
-	DisableDefaultOutput         types.Bool   `tfsdk:"disable_default_output"`
+	SubscriptionID               types.String     `tfsdk:"subscription_id"`
+	ClientID                     types.String     `tfsdk:"client_id"`
+	ClientIDFilePath             types.String     `tfsdk:"client_id_file_path"`
+	TenantID                     types.String     `tfsdk:"tenant_id"`
+	AuxiliaryTenantIDs           types.List       `tfsdk:"auxiliary_tenant_ids"`
+	Endpoint                     types.List       `tfsdk:"endpoint"`
+	Environment                  types.String     `tfsdk:"environment"`
+	MetadataHost                 types.String     `tfsdk:"metadata_host"`
+	ClientCertificate            types.String     `tfsdk:"client_certificate"`
+	ClientCertificatePath        types.String     `tfsdk:"client_certificate_path"`
+	ClientCertificatePassword    types.String     `tfsdk:"client_certificate_password"`
+	ClientSecret                 types.String     `tfsdk:"client_secret"`
+	ClientSecretFilePath         types.String     `tfsdk:"client_secret_file_path"`
+	SkipProviderRegistration     types.Bool       `tfsdk:"skip_provider_registration"`
+	OIDCRequestToken             types.String     `tfsdk:"oidc_request_token"`
+	OIDCRequestURL               types.String     `tfsdk:"oidc_request_url"`
+	OIDCToken                    types.String     `tfsdk:"oidc_token"`
+	OIDCTokenFilePath            types.String     `tfsdk:"oidc_token_file_path"`
+	OIDCAzureServiceConnectionID types.String     `tfsdk:"oidc_azure_service_connection_id"`
+	UseOIDC                      types.Bool       `tfsdk:"use_oidc"`
+	UseCLI                       types.Bool       `tfsdk:"use_cli"`
+	UseMSI                       types.Bool       `tfsdk:"use_msi"`
+	UseAKSWorkloadIdentity       types.Bool       `tfsdk:"use_aks_workload_identity"`
+	PartnerID                    types.String     `tfsdk:"partner_id"`
+	CustomCorrelationRequestID   types.String     `tfsdk:"custom_correlation_request_id"`
+	DisableCorrelationRequestID  types.Bool       `tfsdk:"disable_correlation_request_id"`
+	DisableTerraformPartnerID    types.Bool       `tfsdk:"disable_terraform_partner_id"`
+	DefaultName                  types.String     `tfsdk:"default_name"`
+	DefaultLocation              types.String     `tfsdk:"default_location"`
+	DefaultTags                  types.Map        `tfsdk:"default_tags"`
+	EnablePreflight              types.Bool       `tfsdk:"enable_preflight"`
+	EnableWhatIf                 types.Bool       `tfsdk:"enable_what_if"`
+	DisableDefaultOutput         types.Bool       `tfsdk:"disable_default_output"`
+	ApiVersionWarningsAsErrors   types.Bool       `tfsdk:"api_version_warnings_as_errors"`
+	DefaultRetry                 retry.RetryValue `tfsdk:"default_retry"`
+	DefaultPolling               types.Object     `tfsdk:"default_polling"`
 }
 
 func (model providerData) GetClientId() (*string, error) {
@@ -149,6 +158,17 @@ type providerEndpointData struct {
 	ActiveDirectoryAuthorityHost types.String `tfsdk:"active_directory_authority_host"`
 	ResourceManagerEndpoint      types.String `tfsdk:"resource_manager_endpoint"`
 	ResourceManagerAudience      types.String `tfsdk:"resource_manager_audience"`
+	DataPlaneEndpoints           types.Map    `tfsdk:"data_plane_endpoints"`
+}
+
+type providerDataPlaneEndpointData struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Audience types.String `tfsdk:"audience"`
+}
+
+var providerDataPlaneEndpointAttrTypes = map[string]attr.Type{
+	"endpoint": types.StringType,
+	"audience": types.StringType,
 }
 
 func (p Provider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -206,6 +226,24 @@ func (p Provider) Schema(ctx context.Context, request provider.SchemaRequest, re
 							Optional:            true,
 							MarkdownDescription: "The Azure Active Directory login endpoint to use. This can also be sourced from the `ARM_ACTIVE_DIRECTORY_AUTHORITY_HOST` Environment Variable. Defaults to `https://login.microsoftonline.com/` for public cloud.",
 						},
+
+						"data_plane_endpoints": schema.MapNestedAttribute{
+							Optional:            true,
+							MarkdownDescription: "A map of data plane service endpoints which override the defaults of the selected `environment`. The key is the service name, for example, `AppConfiguration`, `DeviceUpdate`, `DigitalTwins`, `IoTCentral`, `KeyVault`, `Purview` or `Synapse`. Other service names can be used to register additional data plane services.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"endpoint": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "The endpoint of the data plane service, for example, `https://vault.azure.net`. Requests whose host ends with this endpoint's host are authenticated with the `audience`.",
+									},
+
+									"audience": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "The audience to obtain AD tokens for. Defaults to the `endpoint`.",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -218,13 +256,10 @@ func (p Provider) Schema(ctx context.Context, request provider.SchemaRequest, re
 				MarkdownDescription: "The Cloud Environment which should be used. Possible values are `public`, `usgovernment` and `china`. Defaults to `public`. This can also be sourced from the `ARM_ENVIRONMENT` Environment Variable.",
 			},
 
-			// TODO@mgd: the metadata_host is used to retrieve metadata from Azure to identify current environment, this is used to eliminate Azure Stack usage, in which case the provider doesn't support.
-			// "metadata_host": {
-			// 	Type:        schema.TypeString,
-			// 	Required:    true,
-			// 	DefaultFunc: schema.EnvDefaultFunc("ARM_METADATA_HOSTNAME", ""),
-			// 	Description: "The Hostname which should be used for the Azure Metadata Service.",
-			// },
+			"metadata_host": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The Hostname of the Azure Metadata Service, for example, `management.local.azurestack.external`. When set, the cloud configuration is loaded from `https://<metadata_host>/metadata/endpoints` and the `environment` is ignored. The metadata only contains the `KeyVault` and `Synapse` data plane endpoints, the other data plane services are only available when the metadata describes the public, US Government or China cloud, otherwise they must be configured in the `endpoint.data_plane_endpoints` field. This can also be sourced from the `ARM_METADATA_HOSTNAME` Environment Variable.",
+			},
 
 			// Client Certificate specific fields
 			"client_certificate_path": schema.StringAttribute{
@@ -359,13 +394,27 @@ func (p Provider) Schema(ctx context.Context, request provider.SchemaRequest, re
 
 			"enable_preflight": schema.BoolAttribute{
 				Optional:    true,
-				Description: "Enable Preflight Validation. The default is false. When set to true, the provider will use Preflight to do static validation before really deploying a new resource. When set to false, the provider will disable this validation.",
+				Description: "Enable Preflight Validation. The default is false. When set to true, the provider will use Preflight to do static validation before really deploying a new resource or updating an existing resource with `azapi_update_resource`, and the data plane resources are validated by the validation endpoints of the services if they exist. When set to false, the provider will disable this validation.",
+			},
+
+			"enable_what_if": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Enable What-If. The default is false. When set to true, the provider will submit the planned resource to the deployments What-If API during planning and report the predicted changes as warnings. When set to false, the provider will disable this prediction. The resources which are not deployed to a resource group are predicted in a deployment at their `location` or the `default_location`, and they're skipped if neither is specified.",
 			},
 
 			"disable_default_output": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Disable default output. The default is false. When set to false, the provider will output the read-only properties if `response_export_values` is not specified in the resource block. When set to true, the provider will disable this output.",
 			},
+
+			"api_version_warnings_as_errors": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Treat the api-version warnings as errors. The default is false. The provider warns about the api-versions which are preview versions while a stable version is available, or are more than 2 years behind the latest api-version. When set to true, these warnings are reported as errors, which can be used to forbid preview api-versions.",
+			},
+
+			"default_retry": retry.ProviderSingleNestedAttribute(ctx),
+
+			"default_polling": polling.ProviderSingleNestedAttribute(),
 		},
 	}
 }
@@ -423,6 +472,7 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 		attrTypes["active_directory_authority_host"] = types.StringType
 		attrTypes["resource_manager_endpoint"] = types.StringType
 		attrTypes["resource_manager_audience"] = types.StringType
+		attrTypes["data_plane_endpoints"] = types.MapType{ElemType: types.ObjectType{AttrTypes: providerDataPlaneEndpointAttrTypes}}
 		model.Endpoint = types.ListValueMust(types.ObjectType{
 			AttrTypes: attrTypes,
 		}, []attr.Value{
@@ -430,10 +480,17 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 				"active_directory_authority_host": types.StringValue(activeDirectoryAuthorityHost),
 				"resource_manager_endpoint":       types.StringValue(resourceManagerEndpoint),
 				"resource_manager_audience":       types.StringValue(resourceManagerAudience),
+				"data_plane_endpoints":            types.MapNull(types.ObjectType{AttrTypes: providerDataPlaneEndpointAttrTypes}),
 			}),
 		})
 	}
 
+	if model.MetadataHost.IsNull() {
+		if v := os.Getenv("ARM_METADATA_HOSTNAME"); v != "" {
+			model.MetadataHost = types.StringValue(v)
+		}
+	}
+
 	if model.Environment.IsNull() {
 		if v := os.Getenv("ARM_ENVIRONMENT"); v != "" {
 			model.Environment = types.StringValue(v)
@@ -579,24 +636,44 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 	if model.EnablePreflight.IsNull() {
 		model.EnablePreflight = types.BoolValue(false)
 	}
+	if model.EnableWhatIf.IsNull() {
+		model.EnableWhatIf = types.BoolValue(false)
+	}
 	if model.DisableDefaultOutput.IsNull() {
 		model.DisableDefaultOutput = types.BoolValue(false)
 	}
+	if model.ApiVersionWarningsAsErrors.IsNull() {
+		model.ApiVersionWarningsAsErrors = types.BoolValue(false)
+	}
 
 	var cloudConfig cloud.Configuration
 	env := model.Environment.ValueString()
-	switch strings.ToLower(env) {
-	case "public":
+	switch {
+	case model.MetadataHost.ValueString() != "":
+		config, err := cloudConfigurationFromMetadataHost(ctx, &http.Client{Timeout: 30 * time.Second}, model.MetadataHost.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Failed to load the cloud configuration from the `metadata_host`.", err.Error())
+			return
+		}
+		cloudConfig = config
+	case strings.EqualFold(env, "public"):
 		cloudConfig = cloud.AzurePublic
-	case "usgovernment":
+	case strings.EqualFold(env, "usgovernment"):
 		cloudConfig = cloud.AzureGovernment
-	case "china":
+	case strings.EqualFold(env, "china"):
 		cloudConfig = cloud.AzureChina
 	default:
 		response.Diagnostics.AddError("Invalid `environment` value.", fmt.Sprintf("The `environment` value '%s' is invalid. Valid values are 'public', 'usgovernment' and 'china'.", env))
 		return
 	}
 
+	// copy the services so that the overrides don't leak into the shared cloud configurations
+	services := make(map[cloud.ServiceName]cloud.ServiceConfiguration, len(cloudConfig.Services))
+	for name, serviceConfiguration := range cloudConfig.Services {
+		services[name] = serviceConfiguration
+	}
+	cloudConfig.Services = services
+
 	if elements := model.Endpoint.Elements(); len(elements) != 0 {
 		var endpoint providerEndpointData
 		diags := elements[0].(basetypes.ObjectValue).As(ctx, &endpoint, basetypes.ObjectAsOptions{
@@ -622,6 +699,23 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 		if v := endpoint.ActiveDirectoryAuthorityHost.ValueString(); v != "" {
 			cloudConfig.ActiveDirectoryAuthorityHost = v
 		}
+		if !endpoint.DataPlaneEndpoints.IsNull() && !endpoint.DataPlaneEndpoints.IsUnknown() {
+			dataPlaneEndpoints := make(map[string]providerDataPlaneEndpointData)
+			response.Diagnostics.Append(endpoint.DataPlaneEndpoints.ElementsAs(ctx, &dataPlaneEndpoints, false)...)
+			if response.Diagnostics.HasError() {
+				return
+			}
+			for name, dataPlaneEndpoint := range dataPlaneEndpoints {
+				audience := dataPlaneEndpoint.Audience.ValueString()
+				if audience == "" {
+					audience = dataPlaneEndpoint.Endpoint.ValueString()
+				}
+				cloudConfig.Services[cloud.ServiceName(name)] = cloud.ServiceConfiguration{
+					Endpoint: dataPlaneEndpoint.Endpoint.ValueString(),
+					Audience: audience,
+				}
+			}
+		}
 	}
 
 	var auxTenants []string
@@ -639,6 +733,12 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 		TenantID: model.TenantID.ValueString(),
 	}
 
+	defaultPolling, diags := polling.FromObject(ctx, model.DefaultPolling)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	cred, err := buildChainedTokenCredential(model, option)
 	if err != nil {
 		response.Diagnostics.AddError("Failed to obtain a credential.", err.Error())
@@ -650,11 +750,15 @@ func (p Provider) Configure(ctx context.Context, request provider.ConfigureReque
 		CloudCfg:             cloudConfig,
 		ApplicationUserAgent: buildUserAgent(request.TerraformVersion, model.PartnerID.ValueString(), model.DisableTerraformPartnerID.ValueBool()),
 		Features: features.UserFeatures{
-			DefaultTags:          tags.ExpandTags(model.DefaultTags),
-			DefaultLocation:      location.Normalize(model.DefaultLocation.ValueString()),
-			DefaultNaming:        model.DefaultName.ValueString(),
-			EnablePreflight:      model.EnablePreflight.ValueBool(),
-			DisableDefaultOutput: model.DisableDefaultOutput.ValueBool(),
+			DefaultTags:                tags.ExpandTags(model.DefaultTags),
+			DefaultLocation:            location.Normalize(model.DefaultLocation.ValueString()),
+			DefaultNaming:              model.DefaultName.ValueString(),
+			EnablePreflight:            model.EnablePreflight.ValueBool(),
+			EnableWhatIf:               model.EnableWhatIf.ValueBool(),
+			DisableDefaultOutput:       model.DisableDefaultOutput.ValueBool(),
+			ApiVersionWarningsAsErrors: model.ApiVersionWarningsAsErrors.ValueBool(),
+			DefaultRetry:               model.DefaultRetry.AddDefaultValuesIfUnknownOrNull(),
+			DefaultPolling:             defaultPolling,
 		},
 		SkipProviderRegistration:    model.SkipProviderRegistration.ValueBool(),
 		DisableCorrelationRequestID: model.DisableCorrelationRequestID.ValueBool(),
@@ -712,6 +816,15 @@ func (p Provider) DataSources(ctx context.Context) []func() datasource.DataSourc
 		func() datasource.DataSource {
 			return &services.ClientConfigDataSource{}
 		},
+		func() datasource.DataSource {
+			return &services.DataPlaneResourceDataSource{}
+		},
+		func() datasource.DataSource {
+			return &services.DataPlaneResourceListDataSource{}
+		},
+		func() datasource.DataSource {
+			return &services.DataPlaneActionDataSource{}
+		},
 	}
 
 }
@@ -730,6 +843,9 @@ func (p Provider) Resources(ctx context.Context) []func() resource.Resource {
 		func() resource.Resource {
 			return &services.DataPlaneResource{}
 		},
+		func() resource.Resource {
+			return &services.DataPlaneActionResource{}
+		},
 	}
 }
 
@@ -738,6 +854,9 @@ func (p Provider) EphemeralResources(ctx context.Context) []func() ephemeral.Eph
 		func() ephemeral.EphemeralResource {
 			return &services.ActionEphemeral{}
 		},
+		func() ephemeral.EphemeralResource {
+			return &services.DataPlaneActionEphemeral{}
+		},
 	}
 }
 
@@ -134,17 +134,36 @@ func (t RetryType) ValueFromObject(ctx context.Context, in basetypes.ObjectValue
 			fmt.Sprintf(`%s expected to be basetypes.NumberValue, was: %T`, randomizationFactorAttributeName, randomizationFactorAttribute))
 	}
 
+	retryableStatusCodesAttribute, ok := attributes[retryableStatusCodesAttributeName]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			fmt.Sprintf(`%s is missing from object`, retryableStatusCodesAttributeName))
+
+		return nil, diags
+	}
+
+	retryableStatusCodesVal, ok := retryableStatusCodesAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`%s expected to be basetypes.ListValue, was: %T`, retryableStatusCodesAttributeName, retryableStatusCodesAttribute))
+	}
+
 	if diags.HasError() {
 		return nil, diags
 	}
 
 	return RetryValue{
-		ErrorMessageRegex:   errorMessageRegexVal,
-		IntervalSeconds:     intervalSecondsVal,
-		MaxIntervalSeconds:  maxIntervalSecondsVal,
-		Multiplier:          multiplierVal,
-		RandomizationFactor: randomizationFactorVal,
-		state:               attr.ValueStateKnown,
+		ErrorMessageRegex:    errorMessageRegexVal,
+		IntervalSeconds:      intervalSecondsVal,
+		MaxIntervalSeconds:   maxIntervalSecondsVal,
+		Multiplier:           multiplierVal,
+		RandomizationFactor:  randomizationFactorVal,
+		RetryableStatusCodes: retryableStatusCodesVal,
+		state:                attr.ValueStateKnown,
 	}, diags
 }
 
@@ -301,17 +320,36 @@ func NewRetryValue(attributeTypes map[string]attr.Type, attributes map[string]at
 			fmt.Sprintf(`randomization_factor expected to be basetypes.NumberValue, was: %T`, randomizationFactorAttribute))
 	}
 
+	retryableStatusCodesAttribute, ok := attributes[retryableStatusCodesAttributeName]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`retryable_status_codes is missing from object`)
+
+		return NewRetryValueUnknown(), diags
+	}
+
+	retryableStatusCodesVal, ok := retryableStatusCodesAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`retryable_status_codes expected to be basetypes.ListValue, was: %T`, retryableStatusCodesAttribute))
+	}
+
 	if diags.HasError() {
 		return NewRetryValueUnknown(), diags
 	}
 
 	return RetryValue{
-		ErrorMessageRegex:   errorMessageRegexVal,
-		IntervalSeconds:     intervalSecondsVal,
-		MaxIntervalSeconds:  maxIntervalSecondsVal,
-		Multiplier:          multiplierVal,
-		RandomizationFactor: randomizationFactorVal,
-		state:               attr.ValueStateKnown,
+		ErrorMessageRegex:    errorMessageRegexVal,
+		IntervalSeconds:      intervalSecondsVal,
+		MaxIntervalSeconds:   maxIntervalSecondsVal,
+		Multiplier:           multiplierVal,
+		RandomizationFactor:  randomizationFactorVal,
+		RetryableStatusCodes: retryableStatusCodesVal,
+		state:                attr.ValueStateKnown,
 	}, diags
 }
 
@@ -377,16 +415,17 @@ func (t RetryType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (at
 }
 
 type RetryValue struct {
-	ErrorMessageRegex   types.List   `tfsdk:"error_message_regex"`
-	IntervalSeconds     types.Int64  `tfsdk:"interval_seconds"`
-	MaxIntervalSeconds  types.Int64  `tfsdk:"max_interval_seconds"`
-	Multiplier          types.Number `tfsdk:"multiplier"`
-	RandomizationFactor types.Number `tfsdk:"randomization_factor"`
-	state               attr.ValueState
+	ErrorMessageRegex    types.List   `tfsdk:"error_message_regex"`
+	IntervalSeconds      types.Int64  `tfsdk:"interval_seconds"`
+	MaxIntervalSeconds   types.Int64  `tfsdk:"max_interval_seconds"`
+	Multiplier           types.Number `tfsdk:"multiplier"`
+	RandomizationFactor  types.Number `tfsdk:"randomization_factor"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+	state                attr.ValueState
 }
 
 func (v RetryValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
-	attrTypes := make(map[string]tftypes.Type, 5)
+	attrTypes := make(map[string]tftypes.Type, 6)
 
 	var val tftypes.Value
 	var err error
@@ -398,12 +437,15 @@ func (v RetryValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error)
 	attrTypes[maxIntervalSecondsAttributeName] = basetypes.Int64Type{}.TerraformType(ctx)
 	attrTypes[multiplierAttributeName] = basetypes.NumberType{}.TerraformType(ctx)
 	attrTypes[randomizationFactorAttributeName] = basetypes.NumberType{}.TerraformType(ctx)
+	attrTypes[retryableStatusCodesAttributeName] = basetypes.ListType{
+		ElemType: types.Int64Type,
+	}.TerraformType(ctx)
 
 	objectType := tftypes.Object{AttributeTypes: attrTypes}
 
 	switch v.state {
 	case attr.ValueStateKnown:
-		vals := make(map[string]tftypes.Value, 5)
+		vals := make(map[string]tftypes.Value, 6)
 
 		val, err = v.ErrorMessageRegex.ToTerraformValue(ctx)
 
@@ -445,6 +487,14 @@ func (v RetryValue) ToTerraformValue(ctx context.Context) (tftypes.Value, error)
 
 		vals[randomizationFactorAttributeName] = val
 
+		val, err = v.RetryableStatusCodes.ToTerraformValue(ctx)
+
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals[retryableStatusCodesAttributeName] = val
+
 		if err := tftypes.ValidateValue(objectType, vals); err != nil {
 			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
 		}
@@ -474,11 +524,21 @@ func (v RetryValue) String() string {
 func (v RetryValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	errorMessageRegexVal, d := types.ListValue(types.StringType, v.ErrorMessageRegex.Elements())
+	errorMessageRegexVal := types.ListNull(types.StringType)
+	var d diag.Diagnostics
+	if !v.ErrorMessageRegex.IsNull() && !v.ErrorMessageRegex.IsUnknown() {
+		errorMessageRegexVal, d = types.ListValue(types.StringType, v.ErrorMessageRegex.Elements())
+	}
 
 	diags.Append(d...)
 
-	if d.HasError() {
+	retryableStatusCodesVal := types.ListNull(types.Int64Type)
+	if !v.RetryableStatusCodes.IsNull() && !v.RetryableStatusCodes.IsUnknown() {
+		retryableStatusCodesVal, d = types.ListValue(types.Int64Type, v.RetryableStatusCodes.Elements())
+		diags.Append(d...)
+	}
+
+	if diags.HasError() {
 		return types.ObjectUnknown(map[string]attr.Type{
 			errorMessageRegexAttributeName: basetypes.ListType{
 				ElemType: types.StringType,
@@ -487,6 +547,9 @@ func (v RetryValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, d
 			maxIntervalSecondsAttributeName:  basetypes.Int64Type{},
 			multiplierAttributeName:          basetypes.NumberType{},
 			randomizationFactorAttributeName: basetypes.NumberType{},
+			retryableStatusCodesAttributeName: basetypes.ListType{
+				ElemType: types.Int64Type,
+			},
 		}), diags
 	}
 
@@ -498,6 +561,9 @@ func (v RetryValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, d
 		maxIntervalSecondsAttributeName:  basetypes.Int64Type{},
 		multiplierAttributeName:          basetypes.NumberType{},
 		randomizationFactorAttributeName: basetypes.NumberType{},
+		retryableStatusCodesAttributeName: basetypes.ListType{
+			ElemType: types.Int64Type,
+		},
 	}
 
 	if v.IsNull() {
@@ -511,11 +577,12 @@ func (v RetryValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, d
 	objVal, diags := types.ObjectValue(
 		attributeTypes,
 		map[string]attr.Value{
-			errorMessageRegexAttributeName:   errorMessageRegexVal,
-			intervalSecondsAttributeName:     v.IntervalSeconds,
-			maxIntervalSecondsAttributeName:  v.MaxIntervalSeconds,
-			multiplierAttributeName:          v.Multiplier,
-			randomizationFactorAttributeName: v.RandomizationFactor,
+			errorMessageRegexAttributeName:    errorMessageRegexVal,
+			intervalSecondsAttributeName:      v.IntervalSeconds,
+			maxIntervalSecondsAttributeName:   v.MaxIntervalSeconds,
+			multiplierAttributeName:           v.Multiplier,
+			randomizationFactorAttributeName:  v.RandomizationFactor,
+			retryableStatusCodesAttributeName: retryableStatusCodesVal,
 		})
 
 	return objVal, diags
@@ -556,6 +623,10 @@ func (v RetryValue) Equal(o attr.Value) bool {
 		return false
 	}
 
+	if !v.RetryableStatusCodes.Equal(other.RetryableStatusCodes) {
+		return false
+	}
+
 	return true
 }
 
@@ -574,6 +645,9 @@ func (v RetryValue) AttributeTypes(ctx context.Context) map[string]attr.Type {
 		maxIntervalSecondsAttributeName:  basetypes.Int64Type{},
 		multiplierAttributeName:          basetypes.NumberType{},
 		randomizationFactorAttributeName: basetypes.NumberType{},
+		retryableStatusCodesAttributeName: basetypes.ListType{
+			ElemType: types.Int64Type,
+		},
 	}
 }
 
@@ -603,6 +677,18 @@ func (v RetryValue) GetErrorMessagesRegex() []regexp.Regexp {
 	return res
 }
 
+// GetRetryableStatusCodes returns the HTTP status codes which are considered retryable, it returns nil if none is specified.
+func (v RetryValue) GetRetryableStatusCodes() []int {
+	if v.IsNull() || v.IsUnknown() || v.RetryableStatusCodes.IsNull() || v.RetryableStatusCodes.IsUnknown() {
+		return nil
+	}
+	res := make([]int, len(v.RetryableStatusCodes.Elements()))
+	for i, elem := range v.RetryableStatusCodes.Elements() {
+		res[i] = int(elem.(types.Int64).ValueInt64())
+	}
+	return res
+}
+
 func (v RetryValue) GetIntervalSeconds() int {
 	return v.getInt64AttrValue(intervalSecondsAttributeName)
 }
@@ -654,6 +740,14 @@ func (v RetryValue) getInt64AttrValue(name string) int {
 	}
 }
 
+// OrDefault returns the value itself, or the given default value if the value is null.
+func (v RetryValue) OrDefault(defaultValue RetryValue) RetryValue {
+	if v.IsNull() {
+		return defaultValue
+	}
+	return v
+}
+
 func (v RetryValue) AddDefaultValuesIfUnknownOrNull() RetryValue {
 	if v.IntervalSeconds.IsUnknown() || v.IntervalSeconds.IsNull() {
 		v.IntervalSeconds = basetypes.NewInt64Value(defaultIntervalSeconds)
@@ -667,5 +761,8 @@ func (v RetryValue) AddDefaultValuesIfUnknownOrNull() RetryValue {
 	if v.RandomizationFactor.IsUnknown() || v.RandomizationFactor.IsNull() {
 		v.RandomizationFactor = basetypes.NewNumberValue(big.NewFloat(defaultRandomizationFactor))
 	}
+	if v.RetryableStatusCodes.IsUnknown() {
+		v.RetryableStatusCodes = basetypes.NewListNull(types.Int64Type)
+	}
 	return v
 }
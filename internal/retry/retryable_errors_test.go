@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,3 +18,28 @@ func TestValueType(t *testing.T) {
 	_, ok := ty.(basetypes.ObjectType)
 	assert.True(t, ok)
 }
+
+func TestOrDefault(t *testing.T) {
+	defaultValue := NewRetryValueNull().AddDefaultValuesIfUnknownOrNull()
+	defaultValue.state = attr.ValueStateKnown
+	defaultValue.ErrorMessageRegex = basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{basetypes.NewStringValue("default")})
+
+	value := NewRetryValueNull().AddDefaultValuesIfUnknownOrNull()
+	value.state = attr.ValueStateKnown
+	value.ErrorMessageRegex = basetypes.NewListValueMust(basetypes.StringType{}, []attr.Value{basetypes.NewStringValue("resource")})
+
+	assert.Equal(t, []string{"default"}, NewRetryValueNull().OrDefault(defaultValue).GetErrorMessages())
+	assert.Equal(t, []string{"resource"}, value.OrDefault(defaultValue).GetErrorMessages())
+	assert.True(t, NewRetryValueNull().OrDefault(NewRetryValueNull()).IsNull())
+}
+
+func TestProviderSingleNestedAttributeValidators(t *testing.T) {
+	ctx := context.Background()
+	resourceAttrs := SingleNestedAttribute(ctx).(schema.SingleNestedAttribute).Attributes
+	providerAttrs := ProviderSingleNestedAttribute(ctx).(providerschema.SingleNestedAttribute).Attributes
+
+	assert.Len(t, providerAttrs[intervalSecondsAttributeName].(providerschema.Int64Attribute).Validators, len(resourceAttrs[intervalSecondsAttributeName].(schema.Int64Attribute).Validators))
+	assert.Len(t, providerAttrs[maxIntervalSecondsAttributeName].(providerschema.Int64Attribute).Validators, len(resourceAttrs[maxIntervalSecondsAttributeName].(schema.Int64Attribute).Validators))
+	assert.Len(t, providerAttrs[errorMessageRegexAttributeName].(providerschema.ListAttribute).Validators, len(resourceAttrs[errorMessageRegexAttributeName].(schema.ListAttribute).Validators))
+	assert.Len(t, providerAttrs[retryableStatusCodesAttributeName].(providerschema.ListAttribute).Validators, len(resourceAttrs[retryableStatusCodesAttributeName].(schema.ListAttribute).Validators))
+}
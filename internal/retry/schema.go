@@ -7,6 +7,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/numberdefault"
@@ -15,13 +17,46 @@ import (
 )
 
 const (
-	intervalSecondsAttributeName     = "interval_seconds"
-	maxIntervalSecondsAttributeName  = "max_interval_seconds"
-	multiplierAttributeName          = "multiplier"
-	randomizationFactorAttributeName = "randomization_factor"
-	errorMessageRegexAttributeName   = "error_message_regex"
+	intervalSecondsAttributeName      = "interval_seconds"
+	maxIntervalSecondsAttributeName   = "max_interval_seconds"
+	multiplierAttributeName           = "multiplier"
+	randomizationFactorAttributeName  = "randomization_factor"
+	errorMessageRegexAttributeName    = "error_message_regex"
+	retryableStatusCodesAttributeName = "retryable_status_codes"
 )
 
+// The validators are shared by the resource level `retry` block and the provider level `default_retry` block.
+
+func intervalSecondsValidators() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(1),
+		int64validator.AtMost(120),
+	}
+}
+
+func maxIntervalSecondsValidators() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(1),
+		int64validator.AtMost(300),
+	}
+}
+
+func errorMessageRegexValidators() []validator.List {
+	return []validator.List{
+		listvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName(retryableStatusCodesAttributeName)),
+		listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
+		listvalidator.UniqueValues(),
+		listvalidator.SizeAtLeast(1),
+	}
+}
+
+func retryableStatusCodesValidators() []validator.List {
+	return []validator.List{
+		listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+		listvalidator.UniqueValues(),
+	}
+}
+
 func SingleNestedAttribute(ctx context.Context) schema.Attribute {
 	return schema.SingleNestedAttribute{
 		MarkdownDescription: "The retry block supports the following arguments:",
@@ -33,10 +68,7 @@ func SingleNestedAttribute(ctx context.Context) schema.Attribute {
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(defaultIntervalSeconds),
-				Validators: []validator.Int64{
-					int64validator.AtLeast(1),
-					int64validator.AtMost(120),
-				},
+				Validators:          intervalSecondsValidators(),
 			},
 
 			maxIntervalSecondsAttributeName: schema.Int64Attribute{
@@ -44,10 +76,7 @@ func SingleNestedAttribute(ctx context.Context) schema.Attribute {
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(defaultMaxIntervalSeconds),
-				Validators: []validator.Int64{
-					int64validator.AtLeast(1),
-					int64validator.AtMost(300),
-				},
+				Validators:          maxIntervalSecondsValidators(),
 			},
 
 			multiplierAttributeName: schema.NumberAttribute{
@@ -66,13 +95,68 @@ func SingleNestedAttribute(ctx context.Context) schema.Attribute {
 
 			errorMessageRegexAttributeName: schema.ListAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "A list of regular expressions to match against error messages. If any of the regular expressions match, the error is considered retryable.",
-				Required:            true,
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
-					listvalidator.UniqueValues(),
-					listvalidator.SizeAtLeast(1),
-				},
+				MarkdownDescription: "A list of regular expressions to match against error messages. If any of the regular expressions match, the error is considered retryable. At least one of `error_message_regex` and `retryable_status_codes` must be specified.",
+				Optional:            true,
+				Validators:          errorMessageRegexValidators(),
+			},
+
+			retryableStatusCodesAttributeName: schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "A list of HTTP status codes which are considered retryable, for example `409` or `429`. When the response contains a `Retry-After` or `x-ms-retry-after-ms` header, the next retry waits at least the specified duration.",
+				Optional:            true,
+				Validators:          retryableStatusCodesValidators(),
+			},
+		},
+		CustomType: RetryType{
+			ObjectType: types.ObjectType{
+				AttrTypes: RetryValue{}.AttributeTypes(ctx),
+			},
+		},
+	}
+}
+
+// ProviderSingleNestedAttribute returns the provider level `default_retry` block. The provider schema doesn't support default values,
+// so the defaults are applied by AddDefaultValuesIfUnknownOrNull when the provider is configured.
+func ProviderSingleNestedAttribute(ctx context.Context) providerschema.Attribute {
+	return providerschema.SingleNestedAttribute{
+		MarkdownDescription: "The default retry block which is used by all resources and data sources that don't specify the `retry` block. It supports the following arguments:",
+		Optional:            true,
+		Attributes: map[string]providerschema.Attribute{
+
+			intervalSecondsAttributeName: providerschema.Int64Attribute{
+				MarkdownDescription: "The base number of seconds to wait between retries. Default is `10`.",
+				Optional:            true,
+				Validators:          intervalSecondsValidators(),
+			},
+
+			maxIntervalSecondsAttributeName: providerschema.Int64Attribute{
+				MarkdownDescription: "The maximum number of seconds to wait between retries. Default is `180`.",
+				Optional:            true,
+				Validators:          maxIntervalSecondsValidators(),
+			},
+
+			multiplierAttributeName: providerschema.NumberAttribute{
+				MarkdownDescription: "The multiplier to apply to the interval between retries. Default is `1.5`.",
+				Optional:            true,
+			},
+
+			randomizationFactorAttributeName: providerschema.NumberAttribute{
+				Optional:            true,
+				MarkdownDescription: "The randomization factor to apply to the interval between retries. The formula for the randomized interval is: `RetryInterval * (random value in range [1 - RandomizationFactor, 1 + RandomizationFactor])`. Therefore set to zero `0.0` for no randomization. Default is `0.5`.",
+			},
+
+			errorMessageRegexAttributeName: providerschema.ListAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "A list of regular expressions to match against error messages. If any of the regular expressions match, the error is considered retryable. At least one of `error_message_regex` and `retryable_status_codes` must be specified.",
+				Optional:            true,
+				Validators:          errorMessageRegexValidators(),
+			},
+
+			retryableStatusCodesAttributeName: providerschema.ListAttribute{
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "A list of HTTP status codes which are considered retryable, for example `409` or `429`. When the response contains a `Retry-After` or `x-ms-retry-after-ms` header, the next retry waits at least the specified duration.",
+				Optional:            true,
+				Validators:          retryableStatusCodesValidators(),
 			},
 		},
 		CustomType: RetryType{
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"reflect"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/docstrings"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
@@ -19,6 +21,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier/planmodifierdynamic"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/preflight"
 	"github.com/Azure/terraform-provider-azapi/internal/tf"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/cenkalti/backoff/v4"
@@ -46,10 +49,13 @@ type DataPlaneResourceModel struct {
 	Body                          types.Dynamic    `tfsdk:"body"`
 	IgnoreCasing                  types.Bool       `tfsdk:"ignore_casing"`
 	IgnoreMissingProperty         types.Bool       `tfsdk:"ignore_missing_property"`
+	IgnoreBodyChanges             types.List       `tfsdk:"ignore_body_changes"`
+	UnorderedArrays               types.List       `tfsdk:"unordered_arrays"`
 	ReplaceTriggersExternalValues types.Dynamic    `tfsdk:"replace_triggers_external_values"`
 	ReplaceTriggersRefs           types.List       `tfsdk:"replace_triggers_refs"`
 	ResponseExportValues          types.Dynamic    `tfsdk:"response_export_values"`
 	Retry                         retry.RetryValue `tfsdk:"retry"`
+	Polling                       types.Object     `tfsdk:"polling"`
 	Locks                         types.List       `tfsdk:"locks"`
 	Output                        types.Dynamic    `tfsdk:"output"`
 	Timeouts                      timeouts.Value   `tfsdk:"timeouts"`
@@ -71,6 +77,7 @@ var _ resource.Resource = &DataPlaneResource{}
 var _ resource.ResourceWithConfigure = &DataPlaneResource{}
 var _ resource.ResourceWithModifyPlan = &DataPlaneResource{}
 var _ resource.ResourceWithUpgradeState = &DataPlaneResource{}
+var _ resource.ResourceWithImportState = &DataPlaneResource{}
 
 func (r *DataPlaneResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	tflog.Debug(ctx, "Configuring azapi_data_plane_resource")
@@ -158,6 +165,24 @@ func (r *DataPlaneResource) Schema(ctx context.Context, request resource.SchemaR
 				MarkdownDescription: docstrings.IgnoreMissingProperty(),
 			},
 
+			"ignore_body_changes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.IgnoreBodyChanges(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
+			"unordered_arrays": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.UnorderedArrays(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
 			"response_export_values": schema.DynamicAttribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.Dynamic{
@@ -168,6 +193,8 @@ func (r *DataPlaneResource) Schema(ctx context.Context, request resource.SchemaR
 
 			"retry": retry.SingleNestedAttribute(ctx),
 
+			"polling": polling.SingleNestedAttribute(),
+
 			"replace_triggers_external_values": schema.DynamicAttribute{
 				Optional: true,
 				MarkdownDescription: "Will trigger a replace of the resource when the value changes and is not `null`. This can be used by practitioners to force a replace of the resource when certain values change, e.g. changing the SKU of a virtual machine based on the value of variables or locals. " +
@@ -302,6 +329,11 @@ func (r *DataPlaneResource) ModifyPlan(ctx context.Context, request resource.Mod
 		return
 	}
 
+	// the body in state is kept if the changes are only at the paths in ignore_body_changes or in the order of the unordered arrays
+	if state != nil && !isBodyChanged(plan.Body, state.Body, plan.IgnoreBodyChanges, plan.UnorderedArrays) {
+		plan.Body = state.Body
+	}
+
 	if state == nil || !plan.ResponseExportValues.Equal(state.ResponseExportValues) || !dynamic.SemanticallyEqual(plan.Body, state.Body) {
 		plan.Output = basetypes.NewDynamicUnknown()
 	} else {
@@ -310,6 +342,21 @@ func (r *DataPlaneResource) ModifyPlan(ctx context.Context, request resource.Mod
 
 	response.Diagnostics.Append(response.Plan.Set(ctx, plan)...)
 
+	// the output is unknown if the body is changed
+	if r.ProviderData.Features.EnablePreflight && plan.Output.IsUnknown() && dynamic.IsFullyKnown(plan.Body) &&
+		!plan.Type.IsUnknown() && !plan.ParentID.IsUnknown() && !plan.Name.IsUnknown() {
+		id, err := parse.NewDataPlaneResourceId(plan.Name.ValueString(), plan.ParentID.ValueString(), plan.Type.ValueString())
+		if err == nil && preflight.IsDataPlaneSupported(id.AzureResourceType) {
+			var body interface{}
+			if err := unmarshalBody(plan.Body, &body); err == nil {
+				if err := preflight.ValidateDataPlane(ctx, r.ProviderData.DataPlaneClient, id, body); err != nil {
+					response.Diagnostics.AddError("Preflight Validation: Invalid configuration", err.Error())
+					return
+				}
+			}
+		}
+	}
+
 	// Check if any paths in replace_triggers_refs have changed
 	if state != nil && plan != nil && !plan.ReplaceTriggersRefs.IsNull() {
 		refPaths := make(map[string]string)
@@ -390,20 +437,8 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 		}
 	}
 
-	var client clients.DataPlaneRequester
-	client = r.ProviderData.DataPlaneClient
-	if !model.Retry.IsNull() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(timeout),
-		)
-		tflog.Debug(ctx, "azapi_data_plane_resource.CreateUpdate is using retry")
-		client = r.ProviderData.DataPlaneClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	retryValue := model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry)
+	client := retryDataPlaneClient(ctx, r.ProviderData.DataPlaneClient, retryValue, timeout)
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -427,6 +462,19 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 		diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
 		return
 	}
+
+	// the current values of the resource are sent at the paths in ignore_body_changes
+	if ignoreBodyChanges := AsStringList(model.IgnoreBodyChanges); !isNewResource && len(ignoreBodyChanges) != 0 {
+		existing, err := client.Get(ctx, id, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
+		if err != nil {
+			diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+			return
+		}
+		if out, ok := utils.KeepValuesAtPaths(body, existing, ignoreBodyChanges).(map[string]interface{}); ok {
+			body = out
+		}
+	}
+
 	lockIds := AsStringList(model.Locks)
 	slices.Sort(lockIds)
 	for _, lockId := range lockIds {
@@ -434,7 +482,12 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 		defer locks.UnlockByID(lockId)
 	}
 
-	_, err = client.CreateOrUpdateThenPoll(ctx, id, body, clients.NewRequestOptions(AsMapOfString(model.CreateHeaders), AsMapOfLists(model.CreateQueryParameters)))
+	pollingOptions, diags := buildPollingOptions(ctx, model.Polling, r.ProviderData.Features.DefaultPolling)
+	if diagnostics.Append(diags...); diagnostics.HasError() {
+		return
+	}
+
+	_, err = client.CreateOrUpdateThenPoll(ctx, id, body, clients.NewRequestOptions(AsMapOfString(model.CreateHeaders), AsMapOfLists(model.CreateQueryParameters)).WithPolling(pollingOptions))
 	if err != nil {
 		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %q: %+v", id, err).Error())
 		return
@@ -447,8 +500,8 @@ func (r *DataPlaneResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, s
 			backoff.WithMaxInterval(30*time.Second),
 			backoff.WithMaxElapsedTime(RetryGetAfterPut()),
 		),
-		model.Retry.GetErrorMessagesRegex(),
-		[]int{404},
+		retryValue.GetErrorMessagesRegex(),
+		append([]int{404}, retryValue.GetRetryableStatusCodes()...),
 		[]func(d interface{}) bool{
 			func(d interface{}) bool {
 				return d == nil
@@ -500,20 +553,7 @@ func (r *DataPlaneResource) Read(ctx context.Context, request resource.ReadReque
 	}
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.DataPlaneRequester
-	client = r.ProviderData.DataPlaneClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		tflog.Debug(ctx, "azapi_data_plane_resource.Read is using retry")
-		client = r.ProviderData.DataPlaneClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryDataPlaneClient(ctx, r.ProviderData.DataPlaneClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
 	responseBody, err := client.Get(ctx, id, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
@@ -536,6 +576,8 @@ func (r *DataPlaneResource) Read(ctx context.Context, request resource.ReadReque
 		IgnoreMissingProperty: model.IgnoreMissingProperty.ValueBool(),
 	}
 	body := utils.UpdateObject(requestBody, responseBody, option)
+	body = utils.KeepValuesAtPaths(body, requestBody, AsStringList(model.IgnoreBodyChanges))
+	body = utils.OrderArraysAs(body, requestBody, AsStringList(model.UnorderedArrays))
 
 	data, err := json.Marshal(body)
 	if err != nil {
@@ -593,20 +635,7 @@ func (r *DataPlaneResource) Delete(ctx context.Context, request resource.DeleteR
 
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.DataPlaneRequester
-	client = r.ProviderData.DataPlaneClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(deleteTimeout),
-		)
-		tflog.Debug(ctx, "azapi_data_plane_resource.Delete is using retry")
-		client = r.ProviderData.DataPlaneClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryDataPlaneClient(ctx, r.ProviderData.DataPlaneClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), deleteTimeout)
 
 	lockIds := AsStringList(model.Locks)
 	slices.Sort(lockIds)
@@ -615,8 +644,151 @@ func (r *DataPlaneResource) Delete(ctx context.Context, request resource.DeleteR
 		defer locks.UnlockByID(lockId)
 	}
 
-	_, err = client.DeleteThenPoll(ctx, id, clients.NewRequestOptions(AsMapOfString(model.DeleteHeaders), AsMapOfLists(model.DeleteQueryParameters)))
+	pollingOptions, diags := buildPollingOptions(ctx, model.Polling, r.ProviderData.Features.DefaultPolling)
+	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = client.DeleteThenPoll(ctx, id, clients.NewRequestOptions(AsMapOfString(model.DeleteHeaders), AsMapOfLists(model.DeleteQueryParameters)).WithPolling(pollingOptions))
 	if err != nil && !utils.ResponseErrorWasNotFound(err) {
 		response.Diagnostics.AddError("Failed to delete resource", fmt.Errorf("deleting %s: %+v", id, err).Error())
 	}
 }
+
+func (r *DataPlaneResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("Importing Resource - parsing %q", request.ID))
+
+	id, err := parse.DataPlaneResourceID(request.ID)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Resource ID", fmt.Errorf("parsing Resource ID %q: %+v", request.ID, err).Error())
+		return
+	}
+
+	client := r.ProviderData.DataPlaneClient
+
+	state := r.defaultDataPlaneResourceModel()
+	state.ID = types.StringValue(id.ID())
+	state.Name = types.StringValue(id.Name)
+	state.ParentID = types.StringValue(id.ParentId)
+	state.Type = types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
+
+	responseBody, err := client.Get(ctx, id, clients.NewRequestOptions(AsMapOfString(state.ReadHeaders), AsMapOfLists(state.ReadQueryParameters)))
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("[INFO] Error reading %q - removing from state", id.ID()))
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("resource %q is imported", id.ID()))
+	payload, err := flattenDataPlaneBody(id.AzureResourceType, responseBody)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid body", err.Error())
+		return
+	}
+	state.Body = payload
+
+	output, err := buildOutputFromBody(responseBody, state.ResponseExportValues, nil)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to build output", err.Error())
+		return
+	}
+	state.Output = output
+
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (r *DataPlaneResource) defaultDataPlaneResourceModel() DataPlaneResourceModel {
+	return DataPlaneResourceModel{
+		ID:                            types.StringNull(),
+		Name:                          types.StringNull(),
+		ParentID:                      types.StringNull(),
+		Type:                          types.StringNull(),
+		Body:                          types.Dynamic{},
+		IgnoreCasing:                  types.BoolValue(false),
+		IgnoreMissingProperty:         types.BoolValue(true),
+		IgnoreBodyChanges:             types.ListNull(types.StringType),
+		UnorderedArrays:               types.ListNull(types.StringType),
+		Locks:                         types.ListNull(types.StringType),
+		Output:                        types.DynamicNull(),
+		ReplaceTriggersExternalValues: types.DynamicNull(),
+		ReplaceTriggersRefs:           types.ListNull(types.StringType),
+		ResponseExportValues:          types.DynamicNull(),
+		Retry:                         retry.RetryValue{},
+		Polling:                       types.ObjectNull(polling.AttributeTypes()),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"read":   types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+		CreateHeaders:         types.MapNull(types.StringType),
+		CreateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
+		UpdateHeaders:         types.MapNull(types.StringType),
+		UpdateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
+		DeleteHeaders:         types.MapNull(types.StringType),
+		DeleteQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadHeaders:           types.MapNull(types.StringType),
+		ReadQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
+	}
+}
+
+// dataPlaneWritableFields are the top-level fields which can be set in the request body of the data plane resource types,
+// the keys are the lower-cased resource types. The other fields in the response are set by the service.
+var dataPlaneWritableFields = map[string][]string{
+	"microsoft.appconfiguration/configurationstores/keyvalues": {"value", "content_type", "tags"},
+	"microsoft.digitaltwins/digitaltwinsinstances/eventroutes": {"endpointName", "filter"},
+	"microsoft.keyvault/vaults/certificates/contacts":          {"contacts"},
+	"microsoft.synapse/workspaces/dataflows":                   {"properties"},
+	"microsoft.synapse/workspaces/datasets":                    {"properties"},
+	"microsoft.synapse/workspaces/kqlscripts":                  {"properties"},
+	"microsoft.synapse/workspaces/linkedservices":              {"properties"},
+	"microsoft.synapse/workspaces/notebooks":                   {"properties"},
+	"microsoft.synapse/workspaces/pipelines":                   {"properties"},
+	"microsoft.synapse/workspaces/sparkconfigurations":         {"properties"},
+	"microsoft.synapse/workspaces/sparkjobdefinitions":         {"properties"},
+	"microsoft.synapse/workspaces/sqlscripts":                  {"properties"},
+	"microsoft.synapse/workspaces/triggers":                    {"properties"},
+}
+
+// flattenDataPlaneBody builds the body from the response of a data plane resource, there's no embedded schema for
+// data plane resources, so the response is projected to the writable fields of the resource type if they're known,
+// otherwise only the volatile fields are removed.
+func flattenDataPlaneBody(resourceType string, responseBody interface{}) (types.Dynamic, error) {
+	body := utils.NormalizeObject(responseBody)
+	if fields, ok := dataPlaneWritableFields[strings.ToLower(resourceType)]; ok {
+		out := make(map[string]interface{})
+		if bodyMap, ok := body.(map[string]interface{}); ok {
+			for _, field := range fields {
+				// the empty objects and arrays are returned by the service even if they're not specified
+				switch value := bodyMap[field].(type) {
+				case nil:
+				case map[string]interface{}:
+					if len(value) != 0 {
+						out[field] = value
+					}
+				case []interface{}:
+					if len(value) != 0 {
+						out[field] = value
+					}
+				default:
+					out[field] = value
+				}
+			}
+		}
+		body = out
+	} else {
+		body = utils.RemoveFields(body, append(volatileFieldList(), "last_modified"))
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return dynamic.FromJSONImplied(data)
+}
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneActionDataSource struct{}
+
+func TestAccDataPlaneActionDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.basic(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.key").HasValue("mykey"),
+			),
+		},
+	})
+}
+
+func TestAccDataPlaneActionDataSource_sensitiveOutput(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.sensitiveOutput(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("sensitive_output.value").HasValue("myvalue"),
+			),
+		},
+	})
+}
+
+func (r DataPlaneActionDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/kv/${azapi_data_plane_resource.test.name}"
+  method      = "GET"
+  response_export_values = {
+    key = "key"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
+
+func (r DataPlaneActionDataSource) sensitiveOutput(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/kv/${azapi_data_plane_resource.test.name}"
+  method      = "GET"
+  sensitive_response_export_values = {
+    value = "value"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneActionEphemeral struct{}
+
+func TestAccDataPlaneActionEphemeral_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "ephemeral.azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionEphemeral{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.basic(data),
+			ExternalProviders: externalProvidersAzurerm(),
+		},
+	})
+}
+
+func (r DataPlaneActionEphemeral) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/kv/${azapi_data_plane_resource.test.name}"
+  method      = "GET"
+  response_export_values = {
+    value = "value"
+  }
+
+  // the ephemeral values aren't stored in the state, the output is asserted by the postcondition
+  lifecycle {
+    postcondition {
+      condition     = self.output.value == "myvalue"
+      error_message = "The output value is expected to be myvalue."
+    }
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
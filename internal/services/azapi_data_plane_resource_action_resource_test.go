@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+type DataPlaneActionResource struct{}
+
+func (r DataPlaneActionResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	out := false
+	return &out, nil
+}
+
+func TestAccDataPlaneActionResource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.basic(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check:             resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+func TestAccDataPlaneActionResource_basicWhenDestroy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.basicWhenDestroy(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check:             resource.ComposeTestCheckFunc(),
+		},
+		{
+			Destroy:           true,
+			Config:            r.basicWhenDestroy(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check:             resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+func TestAccDataPlaneActionResource_sensitiveOutput(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_data_plane_resource_action", "test")
+	r := DataPlaneActionResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.sensitiveOutput(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check:             resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+func (r DataPlaneActionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/locks/${azapi_data_plane_resource.test.name}"
+  method      = "PUT"
+  response_export_values = {
+    locked = "locked"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
+
+func (r DataPlaneActionResource) basicWhenDestroy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/locks/${azapi_data_plane_resource.test.name}"
+  method      = "DELETE"
+  when        = "destroy"
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
+
+func (r DataPlaneActionResource) sensitiveOutput(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_data_plane_resource_action" "test" {
+  type        = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  resource_id = "${azapi_data_plane_resource.test.parent_id}/kv/${azapi_data_plane_resource.test.name}"
+  method      = "GET"
+  sensitive_response_export_values = {
+    value = "value"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
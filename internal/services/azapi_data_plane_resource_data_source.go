@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/docstrings"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
+	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type DataPlaneResourceDataSourceModel struct {
+	ID                   types.String     `tfsdk:"id"`
+	Name                 types.String     `tfsdk:"name"`
+	ParentID             types.String     `tfsdk:"parent_id"`
+	Type                 types.String     `tfsdk:"type"`
+	ResponseExportValues types.Dynamic    `tfsdk:"response_export_values"`
+	Output               types.Dynamic    `tfsdk:"output"`
+	Timeouts             timeouts.Value   `tfsdk:"timeouts"`
+	Retry                retry.RetryValue `tfsdk:"retry"`
+	Headers              types.Map        `tfsdk:"headers"`
+	QueryParameters      types.Map        `tfsdk:"query_parameters"`
+}
+
+type DataPlaneResourceDataSource struct {
+	ProviderData *clients.Client
+}
+
+var _ datasource.DataSource = &DataPlaneResourceDataSource{}
+var _ datasource.DataSourceWithConfigure = &DataPlaneResourceDataSource{}
+
+func (r *DataPlaneResourceDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if v, ok := request.ProviderData.(*clients.Client); ok {
+		r.ProviderData = v
+	}
+}
+
+func (r *DataPlaneResourceDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_data_plane_resource"
+}
+
+func (r *DataPlaneResourceDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "This data source can access some existing Azure data plane resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: docstrings.ID(),
+			},
+
+			"type": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsResourceType(),
+				},
+				MarkdownDescription: docstrings.Type(),
+			},
+
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Specifies the name of the Azure resource.",
+			},
+
+			"parent_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					myvalidator.StringIsNotEmpty(),
+				},
+				MarkdownDescription: "The ID of the azure resource in which this resource is located.",
+			},
+
+			"response_export_values": schema.DynamicAttribute{
+				Optional:            true,
+				MarkdownDescription: docstrings.ResponseExportValues(),
+			},
+
+			"output": schema.DynamicAttribute{
+				Computed:            true,
+				MarkdownDescription: docstrings.Output("data.azapi_data_plane_resource"),
+			},
+
+			"retry": retry.SingleNestedAttribute(ctx),
+
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of headers to include in the request",
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				Optional:            true,
+				MarkdownDescription: "A map of query parameters to include in the request",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *DataPlaneResourceDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var model DataPlaneResourceDataSourceModel
+	if response.Diagnostics.Append(request.Config.Get(ctx, &model)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	model.Retry = model.Retry.AddDefaultValuesIfUnknownOrNull()
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	id, err := parse.NewDataPlaneResourceId(model.Name.ValueString(), model.ParentID.ValueString(), model.Type.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Invalid configuration", err.Error())
+		return
+	}
+	if id.AzureResourceId == "" {
+		response.Diagnostics.AddError("Invalid configuration", fmt.Sprintf("resource type %s is not supported", id.AzureResourceType))
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "resource_id", id.ID())
+
+	client := retryDataPlaneClient(ctx, r.ProviderData.DataPlaneClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
+	responseBody, err := client.Get(ctx, id, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)))
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			response.Diagnostics.AddError("Resource not found", fmt.Errorf("resource %q not found", id).Error())
+			return
+		}
+		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("retrieving resource %q: %+v", id, err).Error())
+		return
+	}
+
+	model.ID = basetypes.NewStringValue(id.ID())
+
+	var defaultOutput interface{}
+	if !r.ProviderData.Features.DisableDefaultOutput {
+		// the fields are removed from a copy, so that they can still be exported by response_export_values
+		defaultOutput = utils.RemoveFields(utils.NormalizeObject(responseBody), volatileFieldList())
+	}
+	output, err := buildOutputFromBody(responseBody, model.ResponseExportValues, defaultOutput)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to build output", err.Error())
+		return
+	}
+	model.Output = output
+
+	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,45 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneResourceDataSource struct{}
+
+func TestAccDataPlaneResourceDataSource_appConfigKeyValues(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_data_plane_resource", "test")
+	r := DataPlaneResourceDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.appConfigKeyValues(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("output.key").HasValue("mykey"),
+				check.That(data.ResourceName).Key("output.etag").Exists(),
+			),
+		},
+	})
+}
+
+func (r DataPlaneResourceDataSource) appConfigKeyValues(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_data_plane_resource" "test" {
+  type      = azapi_data_plane_resource.test.type
+  parent_id = azapi_data_plane_resource.test.parent_id
+  name      = azapi_data_plane_resource.test.name
+  response_export_values = {
+    key  = "key"
+    etag = "etag"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
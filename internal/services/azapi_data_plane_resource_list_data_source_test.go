@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
+	"github.com/Azure/terraform-provider-azapi/internal/acceptance/check"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+type DataPlaneListDataSource struct{}
+
+func TestAccDataPlaneListDataSource_appConfigKeyValues(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azapi_data_plane_resource_list", "test")
+	r := DataPlaneListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:            r.appConfigKeyValues(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("output.keys.0").HasValue("mykey"),
+				check.That(data.ResourceName).Key("output.etags.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (r DataPlaneListDataSource) appConfigKeyValues(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azapi_data_plane_resource_list" "test" {
+  type      = "Microsoft.AppConfiguration/configurationStores/keyValues@1.0"
+  parent_id = azapi_data_plane_resource.test.parent_id
+  response_export_values = {
+    keys  = "value[].key"
+    etags = "value[].etag"
+  }
+}
+`, DataPlaneResource{}.appConfigKeyValues(data))
+}
@@ -28,6 +28,21 @@ func TestAccDataPlaneResource_appConfigKeyValues(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
+		{
+			ResourceName:            data.ResourceName,
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateIdFunc:       r.ImportIdFunc,
+			ImportStateVerifyIgnore: defaultIgnores(),
+			ImportStatePersist:      true,
+			ExternalProviders:       externalProvidersAzurerm(),
+		},
+		{
+			// the imported body only contains the writable fields, so there's no diff with the configuration
+			Config:            r.appConfigKeyValues(data),
+			ExternalProviders: externalProvidersAzurerm(),
+			PlanOnly:          true,
+		},
 	})
 }
 
@@ -168,6 +183,16 @@ func (DataPlaneResource) Exists(ctx context.Context, client *clients.Client, sta
 	return nil, fmt.Errorf("checking for presence of existing %s: %+v", id, err)
 }
 
+func (DataPlaneResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["azapi_data_plane_resource.test"].Primary
+	resourceType := state.Attributes["type"]
+	id, err := parse.DataPlaneResourceIDWithResourceType(state.ID, resourceType)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s?api-version=%s", id.AzureResourceId, id.ApiVersion), nil
+}
+
 func (r DataPlaneResource) appConfigKeyValues(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
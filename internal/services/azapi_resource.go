@@ -18,6 +18,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/docstrings"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
@@ -55,16 +56,23 @@ type AzapiResourceModel struct {
 	Identity                      types.List       `tfsdk:"identity"`
 	IgnoreCasing                  types.Bool       `tfsdk:"ignore_casing"`
 	IgnoreMissingProperty         types.Bool       `tfsdk:"ignore_missing_property"`
+	IgnoreBodyChanges             types.List       `tfsdk:"ignore_body_changes"`
+	UnorderedArrays               types.List       `tfsdk:"unordered_arrays"`
 	Location                      types.String     `tfsdk:"location"`
 	Locks                         types.List       `tfsdk:"locks"`
 	Name                          types.String     `tfsdk:"name"`
 	Output                        types.Dynamic    `tfsdk:"output"`
 	ParentID                      types.String     `tfsdk:"parent_id"`
+	Polling                       types.Object     `tfsdk:"polling"`
 	ReplaceTriggersExternalValues types.Dynamic    `tfsdk:"replace_triggers_external_values"`
 	ReplaceTriggersRefs           types.List       `tfsdk:"replace_triggers_refs"`
 	ResponseExportValues          types.Dynamic    `tfsdk:"response_export_values"`
+	SensitiveBody                 types.Dynamic    `tfsdk:"sensitive_body"`
+	SensitiveResponseExportValues types.Dynamic    `tfsdk:"sensitive_response_export_values"`
+	SensitiveOutput               types.Dynamic    `tfsdk:"sensitive_output"`
 	Retry                         retry.RetryValue `tfsdk:"retry"`
 	SchemaValidationEnabled       types.Bool       `tfsdk:"schema_validation_enabled"`
+	CreateOnlyReplacementEnabled  types.Bool       `tfsdk:"create_only_replacement_enabled"`
 	Tags                          types.Map        `tfsdk:"tags"`
 	Timeouts                      timeouts.Value   `tfsdk:"timeouts"`
 	Type                          types.String     `tfsdk:"type"`
@@ -174,6 +182,18 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				},
 			},
 
+			"sensitive_body": schema.DynamicAttribute{
+				Optional:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.Dynamic{
+					myplanmodifier.DynamicUseStateWhen(dynamic.SemanticallyEqual),
+				},
+				MarkdownDescription: docstrings.SensitiveBody(),
+				Validators: []validator.Dynamic{
+					myvalidator.DynamicIsNotStringValidator(),
+				},
+			},
+
 			"replace_triggers_external_values": schema.DynamicAttribute{
 				Optional: true,
 				MarkdownDescription: "Will trigger a replace of the resource when the value changes and is not `null`. This can be used by practitioners to force a replace of the resource when certain values change, e.g. changing the SKU of a virtual machine based on the value of variables or locals. " +
@@ -225,6 +245,24 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: docstrings.IgnoreMissingProperty(),
 			},
 
+			"ignore_body_changes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.IgnoreBodyChanges(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
+			"unordered_arrays": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.UnorderedArrays(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
 			"response_export_values": schema.DynamicAttribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.Dynamic{
@@ -233,6 +271,14 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: docstrings.ResponseExportValues(),
 			},
 
+			"sensitive_response_export_values": schema.DynamicAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Dynamic{
+					myplanmodifier.DynamicUseStateWhen(dynamic.SemanticallyEqual),
+				},
+				MarkdownDescription: docstrings.SensitiveResponseExportValues(),
+			},
+
 			"locks": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -249,11 +295,24 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: docstrings.SchemaValidationEnabled(),
 			},
 
+			"create_only_replacement_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.BoolDefault(true),
+				MarkdownDescription: docstrings.CreateOnlyReplacementEnabled(),
+			},
+
 			"output": schema.DynamicAttribute{
 				Computed:            true,
 				MarkdownDescription: docstrings.Output("azapi_resource"),
 			},
 
+			"sensitive_output": schema.DynamicAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: docstrings.SensitiveOutput("azapi_resource"),
+			},
+
 			"tags": schema.MapAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -266,6 +325,8 @@ func (r *AzapiResource) Schema(ctx context.Context, _ resource.SchemaRequest, re
 
 			"retry": retry.SingleNestedAttribute(ctx),
 
+			"polling": polling.SingleNestedAttribute(),
+
 			"create_headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -423,6 +484,10 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(config.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
 	defer func() {
 		response.Plan.Set(ctx, plan)
 	}()
@@ -431,6 +496,7 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 	// It sets to the state if the state exists, and will set to unknown if the output needs to be updated
 	if state != nil {
 		plan.Output = state.Output
+		plan.SensitiveOutput = state.SensitiveOutput
 	}
 
 	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(config.Type.ValueString())
@@ -440,6 +506,10 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 	}
 	resourceDef, _ := azure.GetResourceDefinition(azureResourceType, apiVersion)
 
+	if state != nil {
+		response.Diagnostics.Append(bodyCompatibilityDiagnostics(state.Type, config.Type, state.Body)...)
+	}
+
 	// for resource group, if parent_id is not specified, set it to subscription id
 	if config.ParentID.IsNull() && strings.EqualFold(azureResourceType, arm.ResourceGroupResourceType.String()) {
 		plan.ParentID = types.StringValue(fmt.Sprintf("/subscriptions/%s", r.ProviderData.Account.GetSubscriptionId()))
@@ -465,10 +535,16 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 		}
 	}
 
+	// the body in state is kept if the changes are only at the paths in ignore_body_changes or in the order of the unordered arrays
+	if state != nil && !isBodyChanged(plan.Body, state.Body, plan.IgnoreBodyChanges, plan.UnorderedArrays) {
+		plan.Body = state.Body
+	}
+
 	isNewResource := state == nil
-	if !dynamic.IsFullyKnown(plan.Body) || isNewResource || !plan.Identity.Equal(state.Identity) ||
+	if !dynamic.IsFullyKnown(plan.Body) || !dynamic.IsFullyKnown(plan.SensitiveBody) || isNewResource || !plan.Identity.Equal(state.Identity) ||
 		!plan.Type.Equal(state.Type) ||
-		!plan.ResponseExportValues.Equal(state.ResponseExportValues) || !dynamic.SemanticallyEqual(plan.Body, state.Body) {
+		!plan.ResponseExportValues.Equal(state.ResponseExportValues) || !dynamic.SemanticallyEqual(plan.Body, state.Body) ||
+		!dynamic.SemanticallyEqual(plan.SensitiveBody, state.SensitiveBody) {
 		plan.Output = basetypes.NewDynamicUnknown()
 	}
 	if !dynamic.IsFullyKnown(plan.Body) {
@@ -486,6 +562,11 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 			response.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
 			return
 		}
+		body, err = mergeSensitiveBody(body, config.SensitiveBody)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid sensitive body", fmt.Sprintf(`The argument "sensitive_body" is invalid: %s`, err.Error()))
+			return
+		}
 
 		plan.Tags = r.tagsWithDefaultTags(config.Tags, body, state, resourceDef)
 		if state == nil || !state.Tags.Equal(plan.Tags) {
@@ -511,6 +592,9 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 				return
 			}
 			body["name"] = plan.Name.ValueString()
+			if plan.Name.IsUnknown() {
+				body["name"] = aztypes.UnknownValue
+			}
 			err = schemaValidation(azureResourceType, apiVersion, resourceDef, body)
 			if err != nil {
 				response.Diagnostics.AddError("Invalid configuration", err.Error())
@@ -558,12 +642,77 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 				response.RequiresReplace.Append(path.Root("body"))
 			}
 		}
+
+		// replace the resource if any create-only property in the body is changed
+		if state != nil && plan.CreateOnlyReplacementEnabled.ValueBool() && resourceDef != nil {
+			var planBody, stateBody interface{}
+			if err := unmarshalBody(plan.Body, &planBody); err != nil {
+				response.Diagnostics.AddError("Invalid plan body configuration", err.Error())
+				return
+			}
+			if err := unmarshalBody(state.Body, &stateBody); err != nil {
+				response.Diagnostics.AddError("Invalid state body configuration", err.Error())
+				return
+			}
+			// the changes at the paths in ignore_body_changes and in the order of the unordered arrays are ignored, the same as isBodyChanged
+			planBody = utils.KeepValuesAtPaths(planBody, stateBody, AsStringList(plan.IgnoreBodyChanges))
+			planBody = utils.OrderArraysAs(planBody, stateBody, AsStringList(plan.UnorderedArrays))
+			if changes := azure.DeployTimeConstantChanges(resourceDef, stateBody, planBody, plan.IgnoreCasing.ValueBool()); len(changes) != 0 {
+				for _, change := range changes {
+					response.Diagnostics.AddAttributeWarning(path.Root("body"), "Create-only property changed",
+						fmt.Sprintf("The property `%s` can only be set when the resource is created, the resource will be replaced. Set `create_only_replacement_enabled` to `false` to disable this behavior.", change))
+				}
+				response.RequiresReplace.Append(path.Root("body"))
+			}
+		}
+	} else if plan.SchemaValidationEnabled.ValueBool() {
+		// validate the known parts of the body, the unknown values are treated as wildcards
+		body := make(map[string]interface{})
+		if err := unmarshalBodyWithUnknownValues(config.Body, &body); err != nil {
+			response.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
+			return
+		}
+		body, err = mergeSensitiveBody(body, config.SensitiveBody)
+		if err != nil {
+			response.Diagnostics.AddError("Invalid sensitive body", fmt.Sprintf(`The argument "sensitive_body" is invalid: %s`, err.Error()))
+			return
+		}
+		if len(body) != 0 {
+			if response.Diagnostics.Append(expandBody(body, *plan)...); response.Diagnostics.HasError() {
+				return
+			}
+			if body["location"] == nil && plan.Location.IsUnknown() && canResourceHaveProperty(resourceDef, "location") {
+				body["location"] = aztypes.UnknownValue
+			}
+			if body["tags"] == nil && plan.Tags.IsUnknown() && canResourceHaveProperty(resourceDef, "tags") {
+				body["tags"] = aztypes.UnknownValue
+			}
+			body["name"] = plan.Name.ValueString()
+			if plan.Name.IsUnknown() {
+				body["name"] = aztypes.UnknownValue
+			}
+			if err := schemaValidation(azureResourceType, apiVersion, resourceDef, body); err != nil {
+				response.Diagnostics.AddError("Invalid configuration", err.Error())
+				return
+			}
+		}
+	}
+
+	// the sensitive output is updated whenever the output is updated
+	if plan.Output.IsUnknown() || isNewResource || !plan.SensitiveResponseExportValues.Equal(state.SensitiveResponseExportValues) {
+		plan.SensitiveOutput = basetypes.NewDynamicUnknown()
 	}
 
 	if r.ProviderData.Features.EnablePreflight && isNewResource && preflight.IsSupported(plan.Type.ValueString(), plan.ParentID.ValueString()) {
 		parentId := plan.ParentID.ValueString()
 		if parentId == "" {
-			placeholder, err := preflight.ParentIdPlaceholder(resourceDef, r.ProviderData.Account.GetSubscriptionId())
+			// the parent resource will be created later, its ID is faked
+			var placeholder string
+			if utils.IsTopLevelResourceType(azureResourceType) {
+				placeholder, err = preflight.ParentIdPlaceholder(resourceDef, r.ProviderData.Account.GetSubscriptionId())
+			} else {
+				placeholder, err = preflight.ChildResourceParentIdPlaceholder(azureResourceType, apiVersion, r.ProviderData.Account.GetSubscriptionId())
+			}
 			if err != nil {
 				return
 			}
@@ -575,23 +724,37 @@ func (r *AzapiResource) ModifyPlan(ctx context.Context, request resource.ModifyP
 			name = preflight.NamePlaceholder()
 		}
 
-		err = preflight.Validate(ctx, r.ProviderData.ResourceClient, plan.Type.ValueString(), parentId, name, plan.Location.ValueString(), plan.Body, plan.Identity)
+		err = preflight.Validate(ctx, r.ProviderData.ResourceClient, plan.Type.ValueString(), parentId, name, plan.Location.ValueString(), plan.Body, plan.SensitiveBody, plan.Identity)
 		if err != nil {
 			response.Diagnostics.AddError("Preflight Validation: Invalid configuration", err.Error())
 			return
 		}
 	}
+
+	// the output is unknown if there's any change to the resource
+	hasChanges := isNewResource || plan.Output.IsUnknown()
+	if r.ProviderData.Features.EnableWhatIf && hasChanges && !plan.ParentID.IsUnknown() && plan.ParentID.ValueString() != "" && !plan.Name.IsUnknown() &&
+		preflight.IsSupported(plan.Type.ValueString(), plan.ParentID.ValueString()) {
+		changes, err := preflight.WhatIf(ctx, r.ProviderData.ResourceClient, plan.Type.ValueString(), plan.ParentID.ValueString(), plan.Name.ValueString(), plan.Location.ValueString(), r.ProviderData.Features.DefaultLocation, plan.Tags, plan.Body, plan.SensitiveBody, plan.Identity)
+		if err != nil {
+			response.Diagnostics.AddWarning("What-If: Failed to predict the changes", err.Error())
+			return
+		}
+		if output := preflight.FormatWhatIfChanges(changes); output != "" {
+			response.Diagnostics.AddWarning("What-If: Predicted changes", output)
+		}
+	}
 }
 
 func (r *AzapiResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
-	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+	r.CreateUpdate(ctx, request.Plan, &response.State, nil, response.Private, &response.Diagnostics)
 }
 
 func (r *AzapiResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
-	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics)
+	r.CreateUpdate(ctx, request.Plan, &response.State, request.Private, response.Private, &response.Diagnostics)
 }
 
-func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseState *tfsdk.State, diagnostics *diag.Diagnostics) {
+func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan, responseState *tfsdk.State, requestPrivate privateState, responsePrivate privateState, diagnostics *diag.Diagnostics) {
 	var plan, state *AzapiResourceModel
 	diagnostics.Append(requestPlan.Get(ctx, &plan)...)
 	diagnostics.Append(responseState.Get(ctx, &state)...)
@@ -622,23 +785,21 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 			return
 		}
 	}
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !plan.Retry.IsNull() {
-		regexps := clients.StringSliceToRegexpSliceMust(plan.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(plan.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(plan.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(plan.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(plan.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(timeout),
-		)
-		tflog.Debug(ctx, "azapi_resource.CreateUpdate is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	retryValue := plan.Retry.OrDefault(r.ProviderData.Features.DefaultRetry)
+	client := retryClient(ctx, r.ProviderData.ResourceClient, retryValue, timeout)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	pollingOptions, diags := buildPollingOptions(ctx, plan.Polling, r.ProviderData.Features.DefaultPolling)
+	if diagnostics.Append(diags...); diagnostics.HasError() {
+		return
+	}
+
+	// resume the interrupted long-running operation before sending any other request
+	if diagnostics.Append(resumeInProgressOperation(ctx, r.ProviderData.ResourceClient, id.ID(), requestPrivate, responsePrivate, clients.DefaultRequestOptions().WithPolling(pollingOptions))...); diagnostics.HasError() {
+		return
+	}
+
 	if isNewResource {
 		// check if the resource already exists using the non-retry client to avoid issue where user specifies
 		// a FooResourceNotFound error as a retryable error
@@ -661,6 +822,11 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 		diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
 		return
 	}
+	body, err = mergeSensitiveBody(body, plan.SensitiveBody)
+	if err != nil {
+		diagnostics.AddError("Invalid sensitive body", fmt.Sprintf(`The argument "sensitive_body" is invalid: %s`, err.Error()))
+		return
+	}
 	if diagnostics.Append(expandBody(body, *plan)...); diagnostics.HasError() {
 		return
 	}
@@ -672,6 +838,18 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 			out, _ := identity.ExpandIdentity(noneIdentity)
 			body["identity"] = out
 		}
+
+		// the current values of the resource are sent at the paths in ignore_body_changes
+		if ignoreBodyChanges := AsStringList(plan.IgnoreBodyChanges); len(ignoreBodyChanges) != 0 {
+			existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(plan.ReadHeaders), AsMapOfLists(plan.ReadQueryParameters)))
+			if err != nil {
+				diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+				return
+			}
+			if out, ok := utils.KeepValuesAtPaths(body, existing, ignoreBodyChanges).(map[string]interface{}); ok {
+				body = out
+			}
+		}
 	}
 
 	// create/update the resource
@@ -686,47 +864,50 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 	if !isNewResource {
 		options = clients.NewRequestOptions(AsMapOfString(plan.UpdateHeaders), AsMapOfLists(plan.UpdateQueryParameters))
 	}
+	options = options.WithPolling(pollingOptions).WithOperationStarted(func(resumeToken string) {
+		// the operation is recorded as soon as it's accepted, so that it can be resumed if the polling is interrupted
+		diagnostics.Append(setInProgressOperation(ctx, responsePrivate, id.ID(), resumeToken)...)
+	})
 	_, err = client.CreateOrUpdate(ctx, id.AzureResourceId, id.ApiVersion, body, options)
 	if err != nil {
 		tflog.Debug(ctx, "azapi_resource.CreateUpdate client call create/update resource failed", map[string]interface{}{
 			"err": err,
 		})
+		// the interrupted operation is kept in the private state, so that it's resumed in the next run instead of sending the request again
+		inProgress := saveInProgressOperation(ctx, responsePrivate, id.ID(), err, diagnostics)
+		if !inProgress {
+			diagnostics.Append(clearInProgressOperation(ctx, responsePrivate)...)
+		}
+		// the new resource is saved in the state so that it can be tracked, the prior state is kept for the existing resource
+		// so that the changes which are not applied are planned again
 		if isNewResource {
-			if responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(plan.ReadHeaders), AsMapOfLists(plan.ReadQueryParameters))); err == nil {
-				// generate the computed fields
+			if inProgress {
+				// the context may be done when the operation is interrupted, use a new one to read the resource
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+				defer cancel()
+			}
+			responseBody, getErr := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(plan.ReadHeaders), AsMapOfLists(plan.ReadQueryParameters)))
+			if getErr == nil || inProgress {
 				plan.ID = types.StringValue(id.ID())
-
-				var defaultOutput interface{}
-				if !r.ProviderData.Features.DisableDefaultOutput {
-					defaultOutput = id.ResourceDef.GetReadOnly(responseBody)
-					defaultOutput = utils.RemoveFields(defaultOutput, volatileFieldList())
-				}
-				output, err := buildOutputFromBody(responseBody, plan.ResponseExportValues, defaultOutput)
-				if err != nil {
-					diagnostics.AddError("Failed to build output", err.Error())
-					return
-				}
-				plan.Output = output
-
-				if bodyMap, ok := responseBody.(map[string]interface{}); ok {
-					if !plan.Identity.IsNull() {
-						planIdentity := identity.FromList(plan.Identity)
-						if v := identity.FlattenIdentity(bodyMap["identity"]); v != nil {
-							planIdentity.TenantID = v.TenantID
-							planIdentity.PrincipalID = v.PrincipalID
-						} else {
-							planIdentity.TenantID = types.StringNull()
-							planIdentity.PrincipalID = types.StringNull()
-						}
-						plan.Identity = identity.ToList(planIdentity)
+				if getErr == nil {
+					if diagnostics.Append(r.flattenComputedFields(plan, id, responseBody)...); diagnostics.HasError() {
+						return
 					}
 				}
+				nullifyUnknownComputedFields(plan)
 				diagnostics.Append(responseState.Set(ctx, plan)...)
 			}
 		}
+		if inProgress {
+			diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: the long-running operation is still in progress, it will be resumed in the next run: %+v", id, err).Error())
+			return
+		}
 		diagnostics.AddError("Failed to create/update resource", fmt.Errorf("creating/updating %s: %+v", id, err).Error())
 		return
 	}
+	diagnostics.Append(clearInProgressOperation(ctx, responsePrivate)...)
+
 	// Create a new retry client to handle specific case of transient 404 or empty body after resource creation
 	clientGetAfterPut := r.ProviderData.ResourceClient.WithRetry(
 		backoff.NewExponentialBackOff(
@@ -734,8 +915,8 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 			backoff.WithMaxInterval(30*time.Second),
 			backoff.WithMaxElapsedTime(RetryGetAfterPut()),
 		),
-		plan.Retry.GetErrorMessagesRegex(),
-		[]int{404},
+		retryValue.GetErrorMessagesRegex(),
+		append([]int{404}, retryValue.GetRetryableStatusCodes()...),
 		[]func(d interface{}) bool{
 			func(d interface{}) bool {
 				return d == nil
@@ -756,44 +937,84 @@ func (r *AzapiResource) CreateUpdate(ctx context.Context, requestPlan tfsdk.Plan
 
 	// generate the computed fields
 	plan.ID = types.StringValue(id.ID())
+	if diagnostics.Append(r.flattenComputedFields(plan, id, responseBody)...); diagnostics.HasError() {
+		return
+	}
+	diagnostics.Append(responseState.Set(ctx, plan)...)
+}
 
+// flattenComputedFields sets the output, sensitive output and identity of the model from the response body of the resource.
+func (r *AzapiResource) flattenComputedFields(model *AzapiResourceModel, id parse.ResourceId, responseBody interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
 	var defaultOutput interface{}
 	if !r.ProviderData.Features.DisableDefaultOutput {
 		defaultOutput = id.ResourceDef.GetReadOnly(responseBody)
 		defaultOutput = utils.RemoveFields(defaultOutput, volatileFieldList())
 	}
-	output, err := buildOutputFromBody(responseBody, plan.ResponseExportValues, defaultOutput)
+	output, err := buildOutputFromBody(responseBody, model.ResponseExportValues, defaultOutput)
 	if err != nil {
-		diagnostics.AddError("Failed to build output", err.Error())
-		return
+		diags.AddError("Failed to build output", err.Error())
+		return diags
+	}
+	model.Output = output
+
+	sensitiveOutput, err := buildOutputFromBody(responseBody, model.SensitiveResponseExportValues, nil)
+	if err != nil {
+		diags.AddError("Failed to build sensitive output", err.Error())
+		return diags
 	}
-	plan.Output = output
+	model.SensitiveOutput = sensitiveOutput
 
 	if bodyMap, ok := responseBody.(map[string]interface{}); ok {
-		if !plan.Identity.IsNull() {
-			planIdentity := identity.FromList(plan.Identity)
+		if !model.Identity.IsNull() {
+			modelIdentity := identity.FromList(model.Identity)
 			if v := identity.FlattenIdentity(bodyMap["identity"]); v != nil {
-				planIdentity.TenantID = v.TenantID
-				planIdentity.PrincipalID = v.PrincipalID
+				modelIdentity.TenantID = v.TenantID
+				modelIdentity.PrincipalID = v.PrincipalID
 			} else {
-				planIdentity.TenantID = types.StringNull()
-				planIdentity.PrincipalID = types.StringNull()
+				modelIdentity.TenantID = types.StringNull()
+				modelIdentity.PrincipalID = types.StringNull()
 			}
-			plan.Identity = identity.ToList(planIdentity)
+			model.Identity = identity.ToList(modelIdentity)
 		}
 	}
-	diagnostics.Append(responseState.Set(ctx, plan)...)
+	return diags
+}
+
+// nullifyUnknownComputedFields sets the computed fields which are still unknown to null, the unknown values can't be saved in the state.
+// They're refreshed when the resource is read.
+func nullifyUnknownComputedFields(model *AzapiResourceModel) {
+	if model.Output.IsUnknown() {
+		model.Output = types.DynamicNull()
+	}
+	if model.SensitiveOutput.IsUnknown() {
+		model.SensitiveOutput = types.DynamicNull()
+	}
+	if !model.Identity.IsNull() && !model.Identity.IsUnknown() {
+		modelIdentity := identity.FromList(model.Identity)
+		if modelIdentity.TenantID.IsUnknown() {
+			modelIdentity.TenantID = types.StringNull()
+		}
+		if modelIdentity.PrincipalID.IsUnknown() {
+			modelIdentity.PrincipalID = types.StringNull()
+		}
+		model.Identity = identity.ToList(modelIdentity)
+	}
 }
 
 func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	r.read(ctx, request.State, &response.State, request.Private, response.Private, &response.Diagnostics)
+}
+
+func (r *AzapiResource) read(ctx context.Context, requestState tfsdk.State, responseState *tfsdk.State, requestPrivate privateState, responsePrivate privateState, diagnostics *diag.Diagnostics) {
 	var model AzapiResourceModel
-	if response.Diagnostics.Append(request.State.Get(ctx, &model)...); response.Diagnostics.HasError() {
+	if diagnostics.Append(requestState.Get(ctx, &model)...); diagnostics.HasError() {
 		return
 	}
 
 	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
 		return
 	}
 
@@ -802,35 +1023,32 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 
 	id, err := parse.ResourceIDWithResourceType(model.ID.ValueString(), model.Type.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError("Error parsing ID", err.Error())
+		diagnostics.AddError("Error parsing ID", err.Error())
 		return
 	}
 
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		tflog.Debug(ctx, "azapi_resource.Read is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
+
+	pollingOptions, diags := buildPollingOptions(ctx, model.Polling, r.ProviderData.Features.DefaultPolling)
+	if diagnostics.Append(diags...); diagnostics.HasError() {
+		return
+	}
+
+	// resume the interrupted long-running operation before reading the resource
+	if diagnostics.Append(resumeInProgressOperation(ctx, r.ProviderData.ResourceClient, id.ID(), requestPrivate, responsePrivate, clients.DefaultRequestOptions().WithPolling(pollingOptions))...); diagnostics.HasError() {
+		return
 	}
 
 	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", id.ID()))
-			response.State.RemoveResource(ctx)
+			responseState.RemoveResource(ctx)
 			return
 		}
-		response.Diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
+		diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("reading %s: %+v", id, err).Error())
 		return
 	}
 
@@ -839,9 +1057,14 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 	state.ParentID = types.StringValue(id.ParentId)
 	state.Type = types.StringValue(fmt.Sprintf("%s@%s", id.AzureResourceType, id.ApiVersion))
 
+	// the states created before create_only_replacement_enabled is introduced don't have this field
+	if state.CreateOnlyReplacementEnabled.IsNull() {
+		state.CreateOnlyReplacementEnabled = types.BoolValue(true)
+	}
+
 	requestBody := make(map[string]interface{})
 	if err := unmarshalBody(model.Body, &requestBody); err != nil {
-		response.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
+		diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
 		return
 	}
 
@@ -892,11 +1115,16 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 		IgnoreCasing:          model.IgnoreCasing.ValueBool(),
 		IgnoreMissingProperty: model.IgnoreMissingProperty.ValueBool(),
 	}
+	if id.ResourceDef != nil {
+		option.Schema = id.ResourceDef.AsTypeBase()
+	}
 	body := utils.UpdateObject(requestBody, responseBody, option)
+	body = utils.KeepValuesAtPaths(body, requestBody, AsStringList(model.IgnoreBodyChanges))
+	body = utils.OrderArraysAs(body, requestBody, AsStringList(model.UnorderedArrays))
 
 	data, err := json.Marshal(body)
 	if err != nil {
-		response.Diagnostics.AddError("Invalid body", err.Error())
+		diagnostics.AddError("Invalid body", err.Error())
 		return
 	}
 	var defaultOutput interface{}
@@ -906,34 +1134,65 @@ func (r *AzapiResource) Read(ctx context.Context, request resource.ReadRequest,
 	}
 	output, err := buildOutputFromBody(responseBody, model.ResponseExportValues, defaultOutput)
 	if err != nil {
-		response.Diagnostics.AddError("Failed to build output", err.Error())
+		diagnostics.AddError("Failed to build output", err.Error())
 		return
 	}
 	state.Output = output
 
+	sensitiveOutput, err := buildOutputFromBody(responseBody, model.SensitiveResponseExportValues, nil)
+	if err != nil {
+		diagnostics.AddError("Failed to build sensitive output", err.Error())
+		return
+	}
+	state.SensitiveOutput = sensitiveOutput
+
 	if !model.Body.IsNull() {
 		payload, err := dynamic.FromJSON(data, model.Body.UnderlyingValue().Type(ctx))
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to parse payload: %s", err.Error()))
 			payload, err = dynamic.FromJSONImplied(data)
 			if err != nil {
-				response.Diagnostics.AddError("Invalid payload", err.Error())
+				diagnostics.AddError("Invalid payload", err.Error())
 				return
 			}
 		}
 		state.Body = payload
 	}
 
-	if v, _ := request.Private.GetKey(ctx, FlagMoveState); v != nil && string(v) == "true" {
+	// the sensitive body is updated in the same way as the body to detect the drift of the sensitive properties
+	if !model.SensitiveBody.IsNull() {
+		sensitiveRequestBody := make(map[string]interface{})
+		if err := unmarshalBody(model.SensitiveBody, &sensitiveRequestBody); err != nil {
+			diagnostics.AddError("Invalid sensitive body", fmt.Sprintf(`The argument "sensitive_body" is invalid: %s`, err.Error()))
+			return
+		}
+		data, err := json.Marshal(utils.UpdateObject(sensitiveRequestBody, responseBody, option))
+		if err != nil {
+			diagnostics.AddError("Invalid sensitive body", err.Error())
+			return
+		}
+		payload, err := dynamic.FromJSON(data, model.SensitiveBody.UnderlyingValue().Type(ctx))
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to parse sensitive payload: %s", err.Error()))
+			payload, err = dynamic.FromJSONImplied(data)
+			if err != nil {
+				diagnostics.AddError("Invalid sensitive payload", err.Error())
+				return
+			}
+		}
+		state.SensitiveBody = payload
+	}
+
+	if v, _ := requestPrivate.GetKey(ctx, FlagMoveState); v != nil && string(v) == "true" {
 		payload, err := flattenBody(responseBody, id.ResourceDef)
 		if err != nil {
-			response.Diagnostics.AddError("Invalid body", err.Error())
+			diagnostics.AddError("Invalid body", err.Error())
 			return
 		}
 		state.Body = payload
 	}
 
-	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+	diagnostics.Append(responseState.Set(ctx, state)...)
 }
 
 func (r *AzapiResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
@@ -956,20 +1215,7 @@ func (r *AzapiResource) Delete(ctx context.Context, request resource.DeleteReque
 		return
 	}
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(deleteTimeout),
-		)
-		tflog.Debug(ctx, "azapi_resource.Delete is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), deleteTimeout)
 
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
@@ -981,7 +1227,18 @@ func (r *AzapiResource) Delete(ctx context.Context, request resource.DeleteReque
 		defer locks.UnlockByID(lockId)
 	}
 
-	_, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.DeleteHeaders), AsMapOfLists(model.DeleteQueryParameters)))
+	pollingOptions, diags := buildPollingOptions(ctx, model.Polling, r.ProviderData.Features.DefaultPolling)
+	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+		return
+	}
+
+	// resume the interrupted long-running operation before deleting the resource
+	if response.Diagnostics.Append(resumeInProgressOperation(ctx, r.ProviderData.ResourceClient, id.ID(), request.Private, response.Private, clients.DefaultRequestOptions().WithPolling(pollingOptions))...); response.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = client.Delete(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.DeleteHeaders), AsMapOfLists(model.DeleteQueryParameters)).WithPolling(pollingOptions))
+	saveInProgressOperation(ctx, response.Private, id.ID(), err, &response.Diagnostics)
 	if err != nil && !utils.ResponseErrorWasNotFound(err) {
 		response.Diagnostics.AddError("Failed to delete resource", fmt.Errorf("deleting %s: %+v", id, err).Error())
 	}
@@ -1172,13 +1429,20 @@ func (r *AzapiResource) defaultAzapiResourceModel() AzapiResourceModel {
 		Identity:                      types.ListNull(identity.Model{}.ModelType()),
 		IgnoreCasing:                  types.BoolValue(false),
 		IgnoreMissingProperty:         types.BoolValue(true),
+		IgnoreBodyChanges:             types.ListNull(types.StringType),
+		UnorderedArrays:               types.ListNull(types.StringType),
 		Locks:                         types.ListNull(types.StringType),
 		Output:                        types.DynamicNull(),
 		ReplaceTriggersExternalValues: types.DynamicNull(),
 		ReplaceTriggersRefs:           types.ListNull(types.StringType),
 		ResponseExportValues:          types.DynamicNull(),
+		SensitiveBody:                 types.DynamicNull(),
+		SensitiveResponseExportValues: types.DynamicNull(),
+		SensitiveOutput:               types.DynamicNull(),
 		Retry:                         retry.RetryValue{},
+		Polling:                       types.ObjectNull(polling.AttributeTypes()),
 		SchemaValidationEnabled:       types.BoolValue(true),
+		CreateOnlyReplacementEnabled:  types.BoolValue(true),
 		Tags:                          types.MapNull(types.StringType),
 		Timeouts: timeouts.Value{
 			Object: types.ObjectNull(map[string]attr.Type{
@@ -1199,6 +1463,22 @@ func (r *AzapiResource) defaultAzapiResourceModel() AzapiResourceModel {
 	}
 }
 
+// mergeSensitiveBody merges the sensitive body into the body, the unknown values in the sensitive body are replaced with the unknown value marker
+func mergeSensitiveBody(body map[string]interface{}, sensitiveBody types.Dynamic) (map[string]interface{}, error) {
+	var sensitiveValue interface{}
+	if err := unmarshalBodyWithUnknownValues(sensitiveBody, &sensitiveValue); err != nil {
+		return nil, err
+	}
+	if sensitiveValue == nil {
+		return body, nil
+	}
+	out, ok := utils.MergeObject(body, sensitiveValue).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the sensitive body must be an object")
+	}
+	return out, nil
+}
+
 func expandBody(body map[string]interface{}, model AzapiResourceModel) diag.Diagnostics {
 	if body == nil {
 		return diag.Diagnostics{}
@@ -10,7 +10,6 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -36,6 +35,7 @@ type ResourceActionDataSourceModel struct {
 	Retry                         retry.RetryValue `tfsdk:"retry"`
 	Headers                       types.Map        `tfsdk:"headers"`
 	QueryParameters               types.Map        `tfsdk:"query_parameters"`
+	SchemaValidationEnabled       types.Bool       `tfsdk:"schema_validation_enabled"`
 }
 
 type ResourceActionDataSource struct {
@@ -137,6 +137,11 @@ func (r *ResourceActionDataSource) Schema(ctx context.Context, request datasourc
 				Optional:            true,
 				MarkdownDescription: "A map of query parameters to include in the request",
 			},
+
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: docstrings.SchemaValidationEnabled(),
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -153,6 +158,10 @@ func (r *ResourceActionDataSource) Read(ctx context.Context, request datasource.
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(model.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
 	model.Retry = model.Retry.AddDefaultValuesIfUnknownOrNull()
 
 	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
@@ -183,21 +192,15 @@ func (r *ResourceActionDataSource) Read(ctx context.Context, request datasource.
 		method = "POST"
 	}
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		tflog.Debug(ctx, "data.azapi_resource_action.Read is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
+	if model.SchemaValidationEnabled.IsNull() || model.SchemaValidationEnabled.ValueBool() {
+		if err := actionSchemaValidation(id.AzureResourceType, id.ApiVersion, model.Action.ValueString(), method, requestBody); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
+			return
+		}
 	}
 
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
+
 	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, method, requestBody, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)))
 	if err != nil {
 		response.Diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
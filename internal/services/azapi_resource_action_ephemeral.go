@@ -12,7 +12,6 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,19 +24,20 @@ import (
 )
 
 type ActionEphemeralModel struct {
-	ID                   types.String     `tfsdk:"id"`
-	Type                 types.String     `tfsdk:"type"`
-	ResourceId           types.String     `tfsdk:"resource_id"`
-	Action               types.String     `tfsdk:"action"`
-	Method               types.String     `tfsdk:"method"`
-	Body                 types.Dynamic    `tfsdk:"body"`
-	Locks                types.List       `tfsdk:"locks"`
-	ResponseExportValues types.Dynamic    `tfsdk:"response_export_values"`
-	Output               types.Dynamic    `tfsdk:"output"`
-	Timeouts             timeouts.Value   `tfsdk:"timeouts"`
-	Retry                retry.RetryValue `tfsdk:"retry"`
-	Headers              types.Map        `tfsdk:"headers"`
-	QueryParameters      types.Map        `tfsdk:"query_parameters"`
+	ID                      types.String     `tfsdk:"id"`
+	Type                    types.String     `tfsdk:"type"`
+	ResourceId              types.String     `tfsdk:"resource_id"`
+	Action                  types.String     `tfsdk:"action"`
+	Method                  types.String     `tfsdk:"method"`
+	Body                    types.Dynamic    `tfsdk:"body"`
+	Locks                   types.List       `tfsdk:"locks"`
+	ResponseExportValues    types.Dynamic    `tfsdk:"response_export_values"`
+	Output                  types.Dynamic    `tfsdk:"output"`
+	Timeouts                timeouts.Value   `tfsdk:"timeouts"`
+	Retry                   retry.RetryValue `tfsdk:"retry"`
+	Headers                 types.Map        `tfsdk:"headers"`
+	QueryParameters         types.Map        `tfsdk:"query_parameters"`
+	SchemaValidationEnabled types.Bool       `tfsdk:"schema_validation_enabled"`
 }
 
 type ActionEphemeral struct {
@@ -139,6 +139,11 @@ func (r *ActionEphemeral) Schema(ctx context.Context, request ephemeral.SchemaRe
 				Optional:            true,
 				MarkdownDescription: "A map of query parameters to include in the request",
 			},
+
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: docstrings.SchemaValidationEnabled(),
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -155,6 +160,10 @@ func (r *ActionEphemeral) Open(ctx context.Context, request ephemeral.OpenReques
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(model.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
 	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
 	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
 		return
@@ -182,6 +191,13 @@ func (r *ActionEphemeral) Open(ctx context.Context, request ephemeral.OpenReques
 		method = "POST"
 	}
 
+	if model.SchemaValidationEnabled.IsNull() || model.SchemaValidationEnabled.ValueBool() {
+		if err := actionSchemaValidation(id.AzureResourceType, id.ApiVersion, model.Action.ValueString(), method, requestBody); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
+			return
+		}
+	}
+
 	lockIds := AsStringList(model.Locks)
 	slices.Sort(lockIds)
 	for _, lockId := range lockIds {
@@ -189,20 +205,7 @@ func (r *ActionEphemeral) Open(ctx context.Context, request ephemeral.OpenReques
 		defer locks.UnlockByID(lockId)
 	}
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		tflog.Debug(ctx, "azapi_resource_action.Read is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
 
 	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, method, requestBody, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)))
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/clients"
 	"github.com/Azure/terraform-provider-azapi/internal/docstrings"
 	"github.com/Azure/terraform-provider-azapi/internal/locks"
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/defaults"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
@@ -16,7 +17,6 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -47,8 +47,10 @@ type ActionResourceModel struct {
 	SensitiveOutput               types.Dynamic    `tfsdk:"sensitive_output"`
 	Timeouts                      timeouts.Value   `tfsdk:"timeouts"`
 	Retry                         retry.RetryValue `tfsdk:"retry"`
+	Polling                       types.Object     `tfsdk:"polling"`
 	Headers                       types.Map        `tfsdk:"headers"`
 	QueryParameters               types.Map        `tfsdk:"query_parameters"`
+	SchemaValidationEnabled       types.Bool       `tfsdk:"schema_validation_enabled"`
 }
 
 type ActionResource struct {
@@ -188,6 +190,8 @@ func (r *ActionResource) Schema(ctx context.Context, request resource.SchemaRequ
 
 			"retry": retry.SingleNestedAttribute(ctx),
 
+			"polling": polling.SingleNestedAttribute(),
+
 			"headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -201,6 +205,13 @@ func (r *ActionResource) Schema(ctx context.Context, request resource.SchemaRequ
 				Optional:            true,
 				MarkdownDescription: "A map of query parameters to include in the request",
 			},
+
+			"schema_validation_enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.BoolDefault(true),
+				MarkdownDescription: docstrings.SchemaValidationEnabled(),
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -230,6 +241,28 @@ func (r *ActionResource) ModifyPlan(ctx context.Context, request resource.Modify
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(config.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	// the unknown values in the body are treated as wildcards, the known parts of the body are validated
+	if plan.SchemaValidationEnabled.ValueBool() && !config.Body.IsUnknown() && !config.Body.IsUnderlyingValueUnknown() && !config.Type.IsUnknown() && !config.ResourceId.IsUnknown() && !config.Action.IsUnknown() {
+		id, err := parse.ResourceIDWithResourceType(config.ResourceId.ValueString(), config.Type.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
+			return
+		}
+		var body interface{}
+		if err := unmarshalBodyWithUnknownValues(config.Body, &body); err != nil {
+			response.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
+			return
+		}
+		if err := actionSchemaValidation(id.AzureResourceType, id.ApiVersion, config.Action.ValueString(), plan.Method.ValueString(), body); err != nil {
+			response.Diagnostics.AddError("Invalid configuration", err.Error())
+			return
+		}
+	}
+
 	if state == nil || !dynamic.SemanticallyEqual(config.Body, state.Body) {
 		plan.Output = basetypes.NewDynamicUnknown()
 		plan.SensitiveOutput = basetypes.NewDynamicUnknown()
@@ -318,6 +351,10 @@ func (r *ActionResource) Read(ctx context.Context, request resource.ReadRequest,
 		state.When = basetypes.NewStringValue("apply")
 	}
 
+	if state.SchemaValidationEnabled.IsNull() {
+		state.SchemaValidationEnabled = basetypes.NewBoolValue(true)
+	}
+
 	response.Diagnostics.Append(response.State.Set(ctx, state)...)
 }
 
@@ -352,22 +389,14 @@ func (r *ActionResource) Action(ctx context.Context, model ActionResourceModel,
 		defer locks.UnlockByID(lockId)
 	}
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(actionTimeout),
-		)
-		tflog.Debug(ctx, "azapi_resource_action.Read is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), actionTimeout)
+
+	pollingOptions, diags := buildPollingOptions(ctx, model.Polling, r.ProviderData.Features.DefaultPolling)
+	if diagnostics.Append(diags...); diagnostics.HasError() {
+		return
 	}
 
-	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, model.Method.ValueString(), requestBody, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)))
+	responseBody, err := client.Action(ctx, id.AzureResourceId, model.Action.ValueString(), id.ApiVersion, model.Method.ValueString(), requestBody, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)).WithPolling(pollingOptions))
 	if err != nil {
 		diagnostics.AddError("Failed to perform action", fmt.Errorf("performing action %s of %q: %+v", model.Action.ValueString(), id, err).Error())
 		return
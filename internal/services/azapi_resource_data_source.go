@@ -16,7 +16,6 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
 	"github.com/Azure/terraform-provider-azapi/utils"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -27,20 +26,22 @@ import (
 )
 
 type AzapiResourceDataSourceModel struct {
-	ID                   types.String     `tfsdk:"id"`
-	Name                 types.String     `tfsdk:"name"`
-	ParentID             types.String     `tfsdk:"parent_id"`
-	ResourceID           types.String     `tfsdk:"resource_id"`
-	Type                 types.String     `tfsdk:"type"`
-	ResponseExportValues types.Dynamic    `tfsdk:"response_export_values"`
-	Location             types.String     `tfsdk:"location"`
-	Identity             types.List       `tfsdk:"identity"`
-	Output               types.Dynamic    `tfsdk:"output"`
-	Tags                 types.Map        `tfsdk:"tags"`
-	Timeouts             timeouts.Value   `tfsdk:"timeouts"`
-	Retry                retry.RetryValue `tfsdk:"retry"`
-	Headers              types.Map        `tfsdk:"headers"`
-	QueryParameters      types.Map        `tfsdk:"query_parameters"`
+	ID                            types.String     `tfsdk:"id"`
+	Name                          types.String     `tfsdk:"name"`
+	ParentID                      types.String     `tfsdk:"parent_id"`
+	ResourceID                    types.String     `tfsdk:"resource_id"`
+	Type                          types.String     `tfsdk:"type"`
+	ResponseExportValues          types.Dynamic    `tfsdk:"response_export_values"`
+	SensitiveResponseExportValues types.Dynamic    `tfsdk:"sensitive_response_export_values"`
+	Location                      types.String     `tfsdk:"location"`
+	Identity                      types.List       `tfsdk:"identity"`
+	Output                        types.Dynamic    `tfsdk:"output"`
+	SensitiveOutput               types.Dynamic    `tfsdk:"sensitive_output"`
+	Tags                          types.Map        `tfsdk:"tags"`
+	Timeouts                      timeouts.Value   `tfsdk:"timeouts"`
+	Retry                         retry.RetryValue `tfsdk:"retry"`
+	Headers                       types.Map        `tfsdk:"headers"`
+	QueryParameters               types.Map        `tfsdk:"query_parameters"`
 }
 
 type AzapiResourceDataSource struct {
@@ -143,11 +144,22 @@ func (r *AzapiResourceDataSource) Schema(ctx context.Context, request datasource
 				MarkdownDescription: docstrings.ResponseExportValues(),
 			},
 
+			"sensitive_response_export_values": schema.DynamicAttribute{
+				Optional:            true,
+				MarkdownDescription: docstrings.SensitiveResponseExportValues(),
+			},
+
 			"output": schema.DynamicAttribute{
 				Computed:            true,
 				MarkdownDescription: docstrings.Output("data.azapi_resource"),
 			},
 
+			"sensitive_output": schema.DynamicAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: docstrings.SensitiveOutput("data.azapi_resource"),
+			},
+
 			"tags": schema.MapAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
@@ -218,6 +230,10 @@ func (r *AzapiResourceDataSource) Read(ctx context.Context, request datasource.R
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(model.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
 	model.Retry = model.Retry.AddDefaultValuesIfUnknownOrNull()
 
 	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
@@ -258,20 +274,7 @@ func (r *AzapiResourceDataSource) Read(ctx context.Context, request datasource.R
 
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		tflog.Debug(ctx, "data.azapi_resource.Read is using retry")
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
 	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.Headers), AsMapOfLists(model.QueryParameters)))
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
@@ -310,5 +313,12 @@ func (r *AzapiResourceDataSource) Read(ctx context.Context, request datasource.R
 	}
 	model.Output = output
 
+	sensitiveOutput, err := buildOutputFromBody(responseBody, model.SensitiveResponseExportValues, nil)
+	if err != nil {
+		response.Diagnostics.AddError("Failed to build sensitive output", err.Error())
+		return
+	}
+	model.SensitiveOutput = sensitiveOutput
+
 	response.Diagnostics.Append(response.State.Set(ctx, &model)...)
 }
@@ -499,6 +499,34 @@ func TestAccGenericResource_withRetry(t *testing.T) {
 	})
 }
 
+func TestAccGenericResource_withRetryStatusCodes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withRetryStatusCodes(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccGenericResource_withPolling(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withPolling(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
 func TestAccGenericResource_headers(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_resource", "test")
 	r := GenericResource{}
@@ -544,6 +572,57 @@ func TestAccGenericResource_replaceTriggersRefs(t *testing.T) {
 	})
 }
 
+func TestAccGenericResource_ignoreBodyChanges(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.ignoreBodyChanges(data, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.ignoreBodyChanges(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccGenericResource_unorderedArrays(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.unorderedArrays(data, `["1.1.1.1", "2.2.2.2"]`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:   r.unorderedArrays(data, `["2.2.2.2", "1.1.1.1"]`),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAccGenericResource_sensitiveBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_resource", "test")
+	r := GenericResource{}
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.sensitiveBody(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("sensitive_output.properties.userName").HasValue("admin"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "sensitive_")...),
+	})
+}
+
 func TestAccGenericResource_defaultOutput(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azapi_resource", "test")
 	r := GenericResource{}
@@ -655,6 +734,57 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomString, testCertBase64)
 }
 
+func (r GenericResource) withRetryStatusCodes(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+
+  retry = {
+    retryable_status_codes = [409, 429]
+    interval_seconds       = 5
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
+func (r GenericResource) withPolling(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+
+  polling = {
+    interval_seconds  = 5
+    honor_retry_after = false
+    max_poll_failures = 3
+  }
+}
+`, r.template(data), data.RandomString)
+}
+
 func (r GenericResource) basicInvalidVersion(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -1952,6 +2082,94 @@ resource "azapi_resource" "test" {
 `, r.template(data), data.RandomInteger, skuName)
 }
 
+func (r GenericResource) ignoreBodyChanges(data acceptance.TestData, publicNetworkAccess bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    properties = {
+      publicNetworkAccess = %[3]t
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+  ignore_body_changes = ["properties.publicNetworkAccess"]
+}
+`, r.template(data), data.RandomString, publicNetworkAccess)
+}
+
+func (r GenericResource) unorderedArrays(data acceptance.TestData, ipAddresses string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Storage/storageAccounts@2023-05-01"
+  parent_id = azapi_resource.resourceGroup.id
+  name      = "acctestsa%[2]s"
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    kind = "StorageV2"
+    properties = {
+      networkAcls = {
+        defaultAction = "Deny"
+        ipRules = [for ip in %[3]s : {
+          action = "Allow"
+          value  = ip
+        }]
+      }
+    }
+    sku = {
+      name = "Standard_LRS"
+    }
+  }
+  unordered_arrays = ["properties.networkAcls.ipRules"]
+}
+`, r.template(data), data.RandomString, ipAddresses)
+}
+
+func (r GenericResource) sensitiveBody(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azapi_resource" "automationAccount" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+}
+
+resource "azapi_resource" "test" {
+  type      = "Microsoft.Automation/automationAccounts/credentials@2023-11-01"
+  name      = "acctest%[2]s"
+  parent_id = azapi_resource.automationAccount.id
+  body = {
+    properties = {
+      userName = "admin"
+    }
+  }
+  sensitive_body = {
+    properties = {
+      password = "P@ssw0rd1234!"
+    }
+  }
+  sensitive_response_export_values = ["properties.userName"]
+}
+`, r.template(data), data.RandomString)
+}
+
 func (r GenericResource) defaultOutput(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -17,8 +17,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/myplanmodifier"
 	"github.com/Azure/terraform-provider-azapi/internal/services/myvalidator"
 	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/Azure/terraform-provider-azapi/internal/services/preflight"
 	"github.com/Azure/terraform-provider-azapi/utils"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -42,6 +42,8 @@ type AzapiUpdateResourceModel struct {
 	Body                  types.Dynamic    `tfsdk:"body"`
 	IgnoreCasing          types.Bool       `tfsdk:"ignore_casing"`
 	IgnoreMissingProperty types.Bool       `tfsdk:"ignore_missing_property"`
+	IgnoreBodyChanges     types.List       `tfsdk:"ignore_body_changes"`
+	UnorderedArrays       types.List       `tfsdk:"unordered_arrays"`
 	ResponseExportValues  types.Dynamic    `tfsdk:"response_export_values"`
 	Locks                 types.List       `tfsdk:"locks"`
 	Output                types.Dynamic    `tfsdk:"output"`
@@ -167,6 +169,24 @@ func (r *AzapiUpdateResource) Schema(ctx context.Context, request resource.Schem
 				MarkdownDescription: docstrings.IgnoreMissingProperty(),
 			},
 
+			"ignore_body_changes": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.IgnoreBodyChanges(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
+			"unordered_arrays": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: docstrings.UnorderedArrays(),
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsBodyPath()),
+				},
+			},
+
 			"response_export_values": schema.DynamicAttribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.Dynamic{
@@ -288,6 +308,15 @@ func (r *AzapiUpdateResource) ModifyPlan(ctx context.Context, request resource.M
 		return
 	}
 
+	if response.Diagnostics.Append(apiVersionDiagnostics(config.Type, r.ProviderData.Features.ApiVersionWarningsAsErrors)...); response.Diagnostics.HasError() {
+		return
+	}
+
+	// the body in state is kept if the changes are only at the paths in ignore_body_changes or in the order of the unordered arrays
+	if state != nil && !isBodyChanged(plan.Body, state.Body, plan.IgnoreBodyChanges, plan.UnorderedArrays) {
+		plan.Body = state.Body
+	}
+
 	if state == nil || !plan.ResponseExportValues.Equal(state.ResponseExportValues) || !dynamic.SemanticallyEqual(plan.Body, state.Body) || !plan.Type.Equal(state.Type) {
 		plan.Output = basetypes.NewDynamicUnknown()
 	} else {
@@ -295,6 +324,60 @@ func (r *AzapiUpdateResource) ModifyPlan(ctx context.Context, request resource.M
 	}
 
 	response.Diagnostics.Append(response.Plan.Set(ctx, plan)...)
+
+	// the output is unknown if the body is changed
+	if r.ProviderData.Features.EnablePreflight && plan.Output.IsUnknown() {
+		response.Diagnostics.Append(r.preflightValidation(ctx, *plan)...)
+	}
+}
+
+// preflightValidation validates the merged body which the update would PUT using the preflight API, the existing resource is read during the plan.
+func (r *AzapiUpdateResource) preflightValidation(ctx context.Context, model AzapiUpdateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !dynamic.IsFullyKnown(model.Body) || model.Type.IsUnknown() || model.ReadHeaders.IsUnknown() || model.ReadQueryParameters.IsUnknown() {
+		return diags
+	}
+	if model.ResourceID.ValueString() == "" && (model.ParentID.IsUnknown() || model.Name.IsUnknown()) {
+		return diags
+	}
+
+	id, err := updateResourceId(model)
+	if err != nil || !preflight.IsSupported(model.Type.ValueString(), id.ParentId) {
+		return diags
+	}
+
+	existing, err := r.ProviderData.ResourceClient.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
+	if err != nil || utils.GetId(existing) == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Skipping preflight validation for resource %s because the existing resource can't be retrieved: %v", id, err))
+		return diags
+	}
+
+	var requestBody interface{}
+	if err := unmarshalBody(model.Body, &requestBody); err != nil {
+		return diags
+	}
+	requestBody = buildUpdateRequestBody(existing, requestBody, model, id)
+	body, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return diags
+	}
+
+	resourceLocation, _ := body["location"].(string)
+	if err := preflight.ValidateObject(ctx, r.ProviderData.ResourceClient, model.Type.ValueString(), id.ParentId, id.Name, resourceLocation, body); err != nil {
+		diags.AddError("Preflight Validation: Invalid configuration", err.Error())
+	}
+	return diags
+}
+
+// buildUpdateRequestBody builds the body which the update would PUT, the body is merged into the existing resource,
+// and the current values of the resource are sent at the paths in ignore_body_changes.
+func buildUpdateRequestBody(existing interface{}, requestBody interface{}, model AzapiUpdateResourceModel, id parse.ResourceId) interface{} {
+	requestBody = utils.MergeObject(existing, requestBody)
+	requestBody = utils.KeepValuesAtPaths(requestBody, existing, AsStringList(model.IgnoreBodyChanges))
+	if id.ResourceDef != nil {
+		requestBody = (*id.ResourceDef).GetWriteOnly(utils.NormalizeObject(requestBody))
+	}
+	return requestBody
 }
 
 func (r *AzapiUpdateResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -329,41 +412,15 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	var id parse.ResourceId
-	// We need to ensure that the ID parsed in create and update is the same to produce consistent results.
-	// In update, all these fields are set, using resource_id and type is able to parse the parent_id and name which are used to build it.
-	// But using parent_id, name and type is not able to parse the original resource_id, because the last resource type segment comes from the type instead of the resource_id.
-	if resourceId := model.ResourceID.ValueString(); len(resourceId) != 0 {
-		buildId, err := parse.ResourceIDWithResourceType(model.ResourceID.ValueString(), model.Type.ValueString())
-		if err != nil {
-			diagnostics.AddError("Invalid configuration", err.Error())
-			return
-		}
-		id = buildId
-	} else {
-		buildId, err := parse.NewResourceID(model.Name.ValueString(), model.ParentID.ValueString(), model.Type.ValueString())
-		if err != nil {
-			diagnostics.AddError("Invalid configuration", err.Error())
-			return
-		}
-		id = buildId
+	id, err := updateResourceId(model)
+	if err != nil {
+		diagnostics.AddError("Invalid configuration", err.Error())
+		return
 	}
 
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(timeout),
-		)
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), timeout)
 	existing, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
 	if err != nil {
 		diagnostics.AddError("Failed to retrieve resource", fmt.Errorf("checking for presence of existing %s: %+v", id, err).Error())
@@ -380,11 +437,7 @@ func (r *AzapiUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan,
 		return
 	}
 
-	requestBody = utils.MergeObject(existing, requestBody)
-
-	if id.ResourceDef != nil {
-		requestBody = (*id.ResourceDef).GetWriteOnly(utils.NormalizeObject(requestBody))
-	}
+	requestBody = buildUpdateRequestBody(existing, requestBody, model, id)
 
 	lockIds := AsStringList(model.Locks)
 	slices.Sort(lockIds)
@@ -453,19 +506,7 @@ func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadReq
 
 	ctx = tflog.SetField(ctx, "resource_id", id.ID())
 
-	var client clients.Requester
-	client = r.ProviderData.ResourceClient
-	if !model.Retry.IsNull() && !model.Retry.IsUnknown() {
-		regexps := clients.StringSliceToRegexpSliceMust(model.Retry.GetErrorMessages())
-		bkof := backoff.NewExponentialBackOff(
-			backoff.WithInitialInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMaxInterval(model.Retry.GetMaxIntervalSecondsAsDuration()),
-			backoff.WithMultiplier(model.Retry.GetMultiplier()),
-			backoff.WithRandomizationFactor(model.Retry.GetRandomizationFactor()),
-			backoff.WithMaxElapsedTime(readTimeout),
-		)
-		client = r.ProviderData.ResourceClient.WithRetry(bkof, regexps, nil, nil)
-	}
+	client := retryClient(ctx, r.ProviderData.ResourceClient, model.Retry.OrDefault(r.ProviderData.Features.DefaultRetry), readTimeout)
 
 	responseBody, err := client.Get(ctx, id.AzureResourceId, id.ApiVersion, clients.NewRequestOptions(AsMapOfString(model.ReadHeaders), AsMapOfLists(model.ReadQueryParameters)))
 	if err != nil {
@@ -495,7 +536,12 @@ func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadReq
 		IgnoreCasing:          model.IgnoreCasing.ValueBool(),
 		IgnoreMissingProperty: model.IgnoreMissingProperty.ValueBool(),
 	}
+	if id.ResourceDef != nil {
+		option.Schema = id.ResourceDef.AsTypeBase()
+	}
 	body := utils.UpdateObject(requestBody, responseBody, option)
+	body = utils.KeepValuesAtPaths(body, requestBody, AsStringList(model.IgnoreBodyChanges))
+	body = utils.OrderArraysAs(body, requestBody, AsStringList(model.UnorderedArrays))
 
 	data, err := json.Marshal(body)
 	if err != nil {
@@ -534,3 +580,14 @@ func (r *AzapiUpdateResource) Read(ctx context.Context, request resource.ReadReq
 func (r *AzapiUpdateResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 
 }
+
+// updateResourceId builds the ID of the resource to update.
+// We need to ensure that the ID parsed in create and update is the same to produce consistent results.
+// In update, all these fields are set, using resource_id and type is able to parse the parent_id and name which are used to build it.
+// But using parent_id, name and type is not able to parse the original resource_id, because the last resource type segment comes from the type instead of the resource_id.
+func updateResourceId(model AzapiUpdateResourceModel) (parse.ResourceId, error) {
+	if resourceId := model.ResourceID.ValueString(); len(resourceId) != 0 {
+		return parse.ResourceIDWithResourceType(resourceId, model.Type.ValueString())
+	}
+	return parse.NewResourceID(model.Name.ValueString(), model.ParentID.ValueString(), model.Type.ValueString())
+}
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/acceptance"
@@ -160,6 +161,25 @@ func TestAccGenericUpdateResource_queryParameters(t *testing.T) {
 	})
 }
 
+func TestAccGenericUpdateResource_preflight(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azapi_update_resource", "test")
+	r := GenericUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.preflight(data, "Basic"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.preflight(data, "Invalid"),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("Preflight Validation: Invalid configuration"),
+		},
+	})
+}
+
 func (r GenericUpdateResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	resourceType := state.Attributes["type"]
 	id, err := parse.ResourceIDWithResourceType(state.ID, resourceType)
@@ -648,3 +668,39 @@ resource "azapi_update_resource" "test" {
 }
 `, r.template(data), data.RandomString)
 }
+
+func (r GenericUpdateResource) preflight(data acceptance.TestData, skuName string) string {
+	return fmt.Sprintf(`
+provider "azapi" {
+  enable_preflight = true
+}
+
+%[1]s
+
+resource "azapi_resource" "automationAccount" {
+  type      = "Microsoft.Automation/automationAccounts@2023-11-01"
+  name      = "acctest-%[2]s"
+  parent_id = azapi_resource.resourceGroup.id
+  location  = azapi_resource.resourceGroup.location
+  body = {
+    properties = {
+      sku = {
+        name = "Basic"
+      }
+    }
+  }
+}
+
+resource "azapi_update_resource" "test" {
+  type        = "Microsoft.Automation/automationAccounts@2023-11-01"
+  resource_id = azapi_resource.automationAccount.id
+  body = {
+    properties = {
+      sku = {
+        name = "%[3]s"
+      }
+    }
+  }
+}
+`, r.template(data), data.RandomString, skuName)
+}
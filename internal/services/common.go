@@ -1,14 +1,24 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"time"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 func RetryGetAfterPut() time.Duration {
@@ -21,6 +31,78 @@ func RetryGetAfterPut() time.Duration {
 	return 2 * time.Minute
 }
 
+// retryBackOff builds the exponential back off of the `retry` block, the retries stop once the timeout elapses.
+// It returns nil if the retry is not configured.
+func retryBackOff(retryValue retry.RetryValue, timeout time.Duration) *backoff.ExponentialBackOff {
+	if retryValue.IsNull() || retryValue.IsUnknown() {
+		return nil
+	}
+	return backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(retryValue.GetIntervalSecondsAsDuration()),
+		backoff.WithMaxInterval(retryValue.GetMaxIntervalSecondsAsDuration()),
+		backoff.WithMultiplier(retryValue.GetMultiplier()),
+		backoff.WithRandomizationFactor(retryValue.GetRandomizationFactor()),
+		backoff.WithMaxElapsedTime(timeout),
+	)
+}
+
+// retryClient returns the resource client which retries on the errors specified in the `retry` block,
+// or the client itself if the retry is not configured.
+func retryClient(ctx context.Context, client *clients.ResourceClient, retryValue retry.RetryValue, timeout time.Duration) clients.Requester {
+	bkof := retryBackOff(retryValue, timeout)
+	if bkof == nil {
+		return client
+	}
+	tflog.Debug(ctx, "using retry")
+	return client.WithRetry(bkof, clients.StringSliceToRegexpSliceMust(retryValue.GetErrorMessages()), retryValue.GetRetryableStatusCodes(), nil)
+}
+
+// retryDataPlaneClient is the data plane counterpart of retryClient.
+func retryDataPlaneClient(ctx context.Context, client *clients.DataPlaneClient, retryValue retry.RetryValue, timeout time.Duration) clients.DataPlaneRequester {
+	bkof := retryBackOff(retryValue, timeout)
+	if bkof == nil {
+		return client
+	}
+	tflog.Debug(ctx, "using retry")
+	return client.WithRetry(bkof, clients.StringSliceToRegexpSliceMust(retryValue.GetErrorMessages()), retryValue.GetRetryableStatusCodes(), nil)
+}
+
+// buildPollingOptions builds the polling options of the long-running operations from the `polling` block,
+// the arguments which are not specified fall back to the provider level `default_polling` block.
+func buildPollingOptions(ctx context.Context, input types.Object, defaultValue polling.PollingValue) (clients.PollingOptions, diag.Diagnostics) {
+	value, diags := polling.FromObject(ctx, input)
+	if diags.HasError() {
+		return clients.PollingOptions{}, diags
+	}
+	value = value.OrDefault(defaultValue)
+	return clients.PollingOptions{
+		Interval:         value.GetInterval(),
+		IgnoreRetryAfter: !value.GetHonorRetryAfter(),
+		MaxFailures:      value.GetMaxPollFailures(),
+	}, diags
+}
+
+// apiVersionDiagnostics checks whether the api-version in the `type` is a preview version which has a stable version,
+// or is years behind the latest api-version. The warnings are reported as errors if `api_version_warnings_as_errors` is enabled.
+func apiVersionDiagnostics(resourceType types.String, warningsAsErrors bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if resourceType.IsNull() || resourceType.IsUnknown() {
+		return diags
+	}
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceType.ValueString())
+	if err != nil {
+		return diags
+	}
+	for _, warning := range azure.ApiVersionWarnings(azureResourceType, apiVersion) {
+		if warningsAsErrors {
+			diags.AddAttributeError(path.Root("type"), "Outdated api-version", warning)
+		} else {
+			diags.AddAttributeWarning(path.Root("type"), "Outdated api-version", warning)
+		}
+	}
+	return diags
+}
+
 func buildOutputFromBody(responseBody interface{}, modelResponseExportValues types.Dynamic, defaultResult interface{}) (types.Dynamic, error) {
 	if modelResponseExportValues.IsNull() {
 		if defaultResult == nil {
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PrivateKeyInProgressOperation is the private state key of the long-running operation which was interrupted,
+// for example, Terraform was interrupted or the operation timed out while polling.
+const PrivateKeyInProgressOperation = "in_progress_operation"
+
+// privateState is implemented by the private state of the resource requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type inProgressOperation struct {
+	ResourceId  string `json:"resource_id"`
+	ResumeToken string `json:"resume_token"`
+}
+
+func getInProgressOperation(ctx context.Context, private privateState) *inProgressOperation {
+	if private == nil {
+		return nil
+	}
+	v, _ := private.GetKey(ctx, PrivateKeyInProgressOperation)
+	if len(v) == 0 {
+		return nil
+	}
+	var operation inProgressOperation
+	if err := json.Unmarshal(v, &operation); err != nil || operation.ResumeToken == "" {
+		tflog.Warn(ctx, fmt.Sprintf("ignoring the invalid in-progress operation in private state: %s", string(v)))
+		return nil
+	}
+	return &operation
+}
+
+func setInProgressOperation(ctx context.Context, private privateState, resourceId string, resumeToken string) diag.Diagnostics {
+	if private == nil {
+		return nil
+	}
+	v, err := json.Marshal(inProgressOperation{
+		ResourceId:  resourceId,
+		ResumeToken: resumeToken,
+	})
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Failed to save the in-progress operation", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, PrivateKeyInProgressOperation, v)
+}
+
+func clearInProgressOperation(ctx context.Context, private privateState) diag.Diagnostics {
+	if private == nil {
+		return nil
+	}
+	return private.SetKey(ctx, PrivateKeyInProgressOperation, nil)
+}
+
+// saveInProgressOperation records the long-running operation in the private state if the error shows it's interrupted.
+// It returns true if the operation is recorded.
+func saveInProgressOperation(ctx context.Context, private privateState, resourceId string, err error, diagnostics *diag.Diagnostics) bool {
+	var inProgressErr *clients.OperationInProgressError
+	if !errors.As(err, &inProgressErr) {
+		return false
+	}
+	tflog.Info(ctx, "saving the interrupted long-running operation in private state", map[string]interface{}{
+		"resource_id": resourceId,
+	})
+	diagnostics.Append(setInProgressOperation(ctx, private, resourceId, inProgressErr.ResumeToken)...)
+	return true
+}
+
+// resumeInProgressOperation resumes polling the interrupted long-running operation recorded in the private state, it must be
+// called before sending any other request of the resource. The record is removed once the operation reaches a terminal state,
+// and an error is returned if the operation failed.
+func resumeInProgressOperation(ctx context.Context, client *clients.ResourceClient, resourceId string, requestPrivate privateState, responsePrivate privateState, options clients.RequestOptions) diag.Diagnostics {
+	var diags diag.Diagnostics
+	operation := getInProgressOperation(ctx, requestPrivate)
+	if operation == nil {
+		return diags
+	}
+	if !strings.EqualFold(operation.ResourceId, resourceId) {
+		return clearInProgressOperation(ctx, responsePrivate)
+	}
+
+	tflog.Info(ctx, "resuming the interrupted long-running operation", map[string]interface{}{
+		"resource_id": resourceId,
+	})
+	_, err := client.ResumeOperation(ctx, operation.ResumeToken, options)
+	if saveInProgressOperation(ctx, responsePrivate, resourceId, err, &diags) {
+		diags.AddError("Failed to resume operation", fmt.Errorf("the interrupted long-running operation of %s is still in progress, it will be resumed in the next run: %+v", resourceId, err).Error())
+		return diags
+	}
+	diags.Append(clearInProgressOperation(ctx, responsePrivate)...)
+	if err != nil {
+		diags.AddError("Failed to resume operation", fmt.Errorf("the interrupted long-running operation of %s failed: %+v", resourceId, err).Error())
+	}
+	return diags
+}
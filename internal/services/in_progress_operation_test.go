@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/features"
+	"github.com/Azure/terraform-provider-azapi/internal/retry"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+		return nil
+	}
+	p[key] = value
+	return nil
+}
+
+func Test_InProgressOperation(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+	resourceId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+
+	var diags diag.Diagnostics
+	if saveInProgressOperation(ctx, private, resourceId, errors.New("failed"), &diags) {
+		t.Fatalf("expect other errors not to be saved")
+	}
+	if operation := getInProgressOperation(ctx, private); operation != nil {
+		t.Fatalf("expect no in-progress operation, got %+v", operation)
+	}
+
+	err := &clients.OperationInProgressError{ResumeToken: `{"type":"operationResult","token":{}}`, Err: context.DeadlineExceeded}
+	if !saveInProgressOperation(ctx, private, resourceId, err, &diags) || diags.HasError() {
+		t.Fatalf("expect the in-progress operation to be saved, got diagnostics %v", diags)
+	}
+	operation := getInProgressOperation(ctx, private)
+	if operation == nil || operation.ResourceId != resourceId || operation.ResumeToken != err.ResumeToken {
+		t.Fatalf("expect the saved in-progress operation, got %+v", operation)
+	}
+
+	clearInProgressOperation(ctx, private)
+	if operation := getInProgressOperation(ctx, private); operation != nil {
+		t.Fatalf("expect the in-progress operation to be removed, got %+v", operation)
+	}
+
+	private[PrivateKeyInProgressOperation] = []byte(`"invalid"`)
+	if operation := getInProgressOperation(ctx, private); operation != nil {
+		t.Fatalf("expect the invalid in-progress operation to be ignored, got %+v", operation)
+	}
+}
+
+type fakeTokenCredential struct{}
+
+func (fakeTokenCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "fake", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func Test_AzapiResourceResumeInProgressOperation(t *testing.T) {
+	resourceId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg"
+	var created, done, failed atomic.Bool
+	var putCount atomic.Int32
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	mux.HandleFunc(resourceId, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			putCount.Add(1)
+			created.Store(true)
+			w.Header().Set("Azure-AsyncOperation", server.URL+"/operations/1")
+			w.WriteHeader(http.StatusCreated)
+		case !created.Load():
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"ResourceGroupNotFound","message":"not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"rg","location":"westus"}`))
+	})
+	mux.HandleFunc("/operations/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if done.Load() {
+			_, _ = w.Write([]byte(`{"status":"Succeeded"}`))
+			return
+		}
+		if failed.Load() {
+			_, _ = w.Write([]byte(`{"status":"Failed","error":{"code":"InternalError","message":"failed"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"InProgress"}`))
+	})
+
+	client, err := clients.NewResourceClient(fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Endpoint: server.URL,
+						Audience: "https://management.core.windows.net/",
+					},
+				},
+			},
+			Transport: server.Client(),
+		},
+		DisableRPRegistration: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	userFeatures := features.Default()
+	userFeatures.DisableDefaultOutput = true
+	r := &AzapiResource{ProviderData: &clients.Client{ResourceClient: client, Features: userFeatures}}
+
+	ctx := context.Background()
+	var schemaResponse resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+	resourceSchema := schemaResponse.Schema
+
+	model := r.defaultAzapiResourceModel()
+	model.ID = types.StringUnknown()
+	model.Name = types.StringValue("rg")
+	model.ParentID = types.StringValue("/subscriptions/00000000-0000-0000-0000-000000000000")
+	model.Type = types.StringValue("Microsoft.Resources/resourceGroups@2021-04-01")
+	model.Location = types.StringValue("westus")
+	model.Body = types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}))
+	model.Retry = retry.NewRetryValueNull()
+	model.Output = types.DynamicUnknown()
+	model.SensitiveOutput = types.DynamicUnknown()
+	plan := tfsdk.Plan{Schema: resourceSchema}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// the polling is interrupted, an error is reported, the operation is saved in the private state and the resource is saved with its ID
+	state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(resourceSchema.Type().TerraformType(ctx), nil)}
+	private := fakePrivateState{}
+	var diags diag.Diagnostics
+	createCtx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	r.CreateUpdate(createCtx, plan, &state, nil, private, &diags)
+	if !diags.HasError() {
+		t.Fatalf("expect an error when the operation is interrupted")
+	}
+	var createdModel AzapiResourceModel
+	if diags := state.Get(ctx, &createdModel); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if createdModel.ID.ValueString() != resourceId {
+		t.Fatalf("expect the resource to be saved with ID %q, got %q", resourceId, createdModel.ID.ValueString())
+	}
+	if operation := getInProgressOperation(ctx, private); operation == nil || operation.ResourceId != resourceId {
+		t.Fatalf("expect the in-progress operation to be saved, got %+v", operation)
+	}
+
+	// the operation is still in progress when the resource is read, it's kept in the private state
+	readCtx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	nextPrivate := fakePrivateState{}
+	diags = nil
+	r.read(readCtx, state, &state, private, nextPrivate, &diags)
+	if !diags.HasError() {
+		t.Fatalf("expect an error when the operation is still in progress")
+	}
+	if operation := getInProgressOperation(ctx, nextPrivate); operation == nil {
+		t.Fatalf("expect the in-progress operation to be kept")
+	}
+
+	// the resumed operation failed, an error is reported and it's removed from the private state
+	failed.Store(true)
+	failedPrivate := fakePrivateState{}
+	for key, value := range nextPrivate {
+		failedPrivate[key] = value
+	}
+	diags = nil
+	r.read(ctx, state, &tfsdk.State{Schema: resourceSchema, Raw: state.Raw.Copy()}, nextPrivate, failedPrivate, &diags)
+	if !diags.HasError() {
+		t.Fatalf("expect an error when the resumed operation failed")
+	}
+	if operation := getInProgressOperation(ctx, failedPrivate); operation != nil {
+		t.Fatalf("expect the failed operation to be removed, got %+v", operation)
+	}
+	failed.Store(false)
+
+	// the operation is resumed and completed when the resource is read, it's removed from the private state
+	done.Store(true)
+	private, nextPrivate = nextPrivate, fakePrivateState{}
+	diags = nil
+	r.read(ctx, state, &state, private, nextPrivate, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if operation := getInProgressOperation(ctx, nextPrivate); operation != nil {
+		t.Fatalf("expect the in-progress operation to be removed, got %+v", operation)
+	}
+	if state.Raw.IsNull() {
+		t.Fatalf("expect the resource to be kept in the state")
+	}
+	if count := putCount.Load(); count != 1 {
+		t.Fatalf("expect the resource to be created once, got %d", count)
+	}
+}
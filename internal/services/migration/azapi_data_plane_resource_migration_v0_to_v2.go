@@ -3,6 +3,7 @@ package migration
 import (
 	"context"
 
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -92,10 +93,13 @@ func AzapiDataPlaneResourceMigrationV0ToV2(ctx context.Context) resource.StateUp
 				Body                          types.Dynamic       `tfsdk:"body"`
 				IgnoreCasing                  types.Bool          `tfsdk:"ignore_casing"`
 				IgnoreMissingProperty         types.Bool          `tfsdk:"ignore_missing_property"`
+				IgnoreBodyChanges             types.List          `tfsdk:"ignore_body_changes"`
+				UnorderedArrays               types.List          `tfsdk:"unordered_arrays"`
 				ReplaceTriggersExternalValues types.Dynamic       `tfsdk:"replace_triggers_external_values"`
 				ReplaceTriggersRefs           types.List          `tfsdk:"replace_triggers_refs"`
 				ResponseExportValues          types.Dynamic       `tfsdk:"response_export_values"`
 				Retry                         retry.RetryValue    `tfsdk:"retry"`
+				Polling                       types.Object        `tfsdk:"polling"`
 				Locks                         types.List          `tfsdk:"locks"`
 				Output                        types.Dynamic       `tfsdk:"output"`
 				Timeouts                      timeouts.Value      `tfsdk:"timeouts"`
@@ -142,10 +146,13 @@ func AzapiDataPlaneResourceMigrationV0ToV2(ctx context.Context) resource.StateUp
 				Locks:                         oldState.Locks,
 				IgnoreCasing:                  oldState.IgnoreCasing,
 				IgnoreMissingProperty:         oldState.IgnoreMissingProperty,
+				IgnoreBodyChanges:             types.ListNull(types.StringType),
+				UnorderedArrays:               types.ListNull(types.StringType),
 				ResponseExportValues:          responseExportValues,
 				ReplaceTriggersExternalValues: types.DynamicNull(),
 				ReplaceTriggersRefs:           types.ListNull(types.StringType),
 				Retry:                         retry.NewRetryValueNull(),
+				Polling:                       types.ObjectNull(polling.AttributeTypes()),
 				Output:                        outputVal,
 				Timeouts:                      oldState.Timeouts,
 			}
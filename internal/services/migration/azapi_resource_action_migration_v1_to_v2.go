@@ -3,6 +3,7 @@ package migration
 import (
 	"context"
 
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -98,8 +99,10 @@ func AzapiResourceActionMigrationV1ToV2(ctx context.Context) resource.StateUpgra
 				SensitiveOutput               types.Dynamic       `tfsdk:"sensitive_output"`
 				Timeouts                      timeouts.Value      `tfsdk:"timeouts"`
 				Retry                         retry.RetryValue    `tfsdk:"retry"`
+				Polling                       types.Object        `tfsdk:"polling"`
 				Headers                       map[string]string   `tfsdk:"headers"`
 				QueryParameters               map[string][]string `tfsdk:"query_parameters"`
+				SchemaValidationEnabled       types.Bool          `tfsdk:"schema_validation_enabled"`
 			}
 
 			var oldState OldModel
@@ -139,6 +142,8 @@ func AzapiResourceActionMigrationV1ToV2(ctx context.Context) resource.StateUpgra
 				SensitiveOutput:               types.DynamicNull(),
 				Timeouts:                      oldState.Timeouts,
 				Retry:                         retry.NewRetryValueNull(),
+				Polling:                       types.ObjectNull(polling.AttributeTypes()),
+				SchemaValidationEnabled:       types.BoolValue(true),
 			}
 
 			response.Diagnostics.Append(response.State.Set(ctx, newState)...)
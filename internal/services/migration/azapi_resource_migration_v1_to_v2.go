@@ -3,6 +3,7 @@ package migration
 import (
 	"context"
 
+	"github.com/Azure/terraform-provider-azapi/internal/polling"
 	"github.com/Azure/terraform-provider-azapi/internal/retry"
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -150,12 +151,19 @@ func AzapiResourceMigrationV1ToV2(ctx context.Context) resource.StateUpgrader {
 				Body                          types.Dynamic       `tfsdk:"body"`
 				Locks                         types.List          `tfsdk:"locks"`
 				SchemaValidationEnabled       types.Bool          `tfsdk:"schema_validation_enabled"`
+				CreateOnlyReplacementEnabled  types.Bool          `tfsdk:"create_only_replacement_enabled"`
 				IgnoreCasing                  types.Bool          `tfsdk:"ignore_casing"`
 				IgnoreMissingProperty         types.Bool          `tfsdk:"ignore_missing_property"`
+				IgnoreBodyChanges             types.List          `tfsdk:"ignore_body_changes"`
+				UnorderedArrays               types.List          `tfsdk:"unordered_arrays"`
 				ReplaceTriggersExternalValues types.Dynamic       `tfsdk:"replace_triggers_external_values"`
 				ReplaceTriggersRefs           types.List          `tfsdk:"replace_triggers_refs"`
 				ResponseExportValues          types.Dynamic       `tfsdk:"response_export_values"`
+				SensitiveBody                 types.Dynamic       `tfsdk:"sensitive_body"`
+				SensitiveResponseExportValues types.Dynamic       `tfsdk:"sensitive_response_export_values"`
+				SensitiveOutput               types.Dynamic       `tfsdk:"sensitive_output"`
 				Retry                         retry.RetryValue    `tfsdk:"retry"`
+				Polling                       types.Object        `tfsdk:"polling"`
 				Output                        types.Dynamic       `tfsdk:"output"`
 				Tags                          types.Map           `tfsdk:"tags"`
 				Timeouts                      timeouts.Value      `tfsdk:"timeouts"`
@@ -201,12 +209,19 @@ func AzapiResourceMigrationV1ToV2(ctx context.Context) resource.StateUpgrader {
 				Body:                          bodyVal,
 				Locks:                         oldState.Locks,
 				SchemaValidationEnabled:       oldState.SchemaValidationEnabled,
+				CreateOnlyReplacementEnabled:  types.BoolValue(true),
 				IgnoreCasing:                  oldState.IgnoreCasing,
 				IgnoreMissingProperty:         oldState.IgnoreMissingProperty,
+				IgnoreBodyChanges:             types.ListNull(types.StringType),
+				UnorderedArrays:               types.ListNull(types.StringType),
 				ReplaceTriggersExternalValues: types.DynamicNull(),
 				ReplaceTriggersRefs:           types.ListNull(types.StringType),
 				ResponseExportValues:          responseExportValues,
+				SensitiveBody:                 types.DynamicNull(),
+				SensitiveResponseExportValues: types.DynamicNull(),
+				SensitiveOutput:               types.DynamicNull(),
 				Retry:                         retry.NewRetryValueNull(),
+				Polling:                       types.ObjectNull(polling.AttributeTypes()),
 				Output:                        outputVal,
 				Tags:                          oldState.Tags,
 				Timeouts:                      oldState.Timeouts,
@@ -107,6 +107,8 @@ func AzapiUpdateResourceMigrationV1ToV2(ctx context.Context) resource.StateUpgra
 				Body                  types.Dynamic       `tfsdk:"body"`
 				IgnoreCasing          types.Bool          `tfsdk:"ignore_casing"`
 				IgnoreMissingProperty types.Bool          `tfsdk:"ignore_missing_property"`
+				IgnoreBodyChanges     types.List          `tfsdk:"ignore_body_changes"`
+				UnorderedArrays       types.List          `tfsdk:"unordered_arrays"`
 				ResponseExportValues  types.Dynamic       `tfsdk:"response_export_values"`
 				Locks                 types.List          `tfsdk:"locks"`
 				Output                types.Dynamic       `tfsdk:"output"`
@@ -150,6 +152,8 @@ func AzapiUpdateResourceMigrationV1ToV2(ctx context.Context) resource.StateUpgra
 				Locks:                 oldState.Locks,
 				IgnoreCasing:          oldState.IgnoreCasing,
 				IgnoreMissingProperty: oldState.IgnoreMissingProperty,
+				IgnoreBodyChanges:     types.ListNull(types.StringType),
+				UnorderedArrays:       types.ListNull(types.StringType),
 				ResponseExportValues:  responseExportValues,
 				Output:                outputVal,
 				Timeouts:              oldState.Timeouts,
@@ -0,0 +1,37 @@
+package myvalidator
+
+import (
+	"context"
+
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsBodyPath struct{}
+
+func (v stringIsBodyPath) Description(ctx context.Context) string {
+	return "validate this is a path in the body"
+}
+
+func (v stringIsBodyPath) MarkdownDescription(ctx context.Context) string {
+	return "validate this is a path in the body"
+}
+
+func (_ stringIsBodyPath) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if _, err := utils.ParseBodyPath(str.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid body path",
+			err.Error())
+	}
+}
+
+func StringIsBodyPath() validator.String {
+	return stringIsBodyPath{}
+}
@@ -2,6 +2,7 @@ package parse
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/utils"
@@ -52,6 +53,45 @@ func NewDataPlaneResourceId(name, parentId, resourceType string) (DataPlaneResou
 	}, nil
 }
 
+// NewDataPlaneCollectionId returns the ID of the collection which contains the resources of the resource type under the parent.
+// The collection URL is built from the url format without the trailing name segment, so the resource types whose url format
+// doesn't end with the name segment, e.g. singleton resources or resources nested under a named resource, can't be listed.
+func NewDataPlaneCollectionId(parentId, resourceType string) (DataPlaneResourceId, error) {
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceType)
+	if err != nil {
+		return DataPlaneResourceId{}, err
+	}
+
+	apiPath := findApiPathByResourceType(azureResourceType)
+	if apiPath == nil {
+		return DataPlaneResourceId{}, fmt.Errorf("resource type %s is not supported", azureResourceType)
+	}
+
+	parts := strings.Split(apiPath.UrlFormat, "/")
+	last := parts[len(parts)-1]
+	if !strings.HasPrefix(last, "{") || strings.HasPrefix(last, "{name=") || last == "{parentId}" {
+		return DataPlaneResourceId{}, fmt.Errorf("resource type %s can't be listed, its url format %s doesn't end with the name segment", azureResourceType, apiPath.UrlFormat)
+	}
+	parts = parts[:len(parts)-1]
+	for i, part := range parts {
+		switch {
+		case part == "{parentId}":
+			parts[i] = parentId
+		case part == "{apiVersion}":
+			parts[i] = apiVersion
+		case strings.HasPrefix(part, "{"):
+			return DataPlaneResourceId{}, fmt.Errorf("resource type %s can't be listed, its url format %s contains the name segment in the middle", azureResourceType, apiPath.UrlFormat)
+		}
+	}
+
+	return DataPlaneResourceId{
+		AzureResourceId:   strings.Join(parts, "/"),
+		ApiVersion:        apiVersion,
+		AzureResourceType: azureResourceType,
+		ParentId:          parentId,
+	}, nil
+}
+
 // DataPlaneResourceIDWithResourceType parses a Resource ID and resource type into an ResourceId struct
 func DataPlaneResourceIDWithResourceType(azureResourceId, resourceType string) (DataPlaneResourceId, error) {
 	azureResourceType, _, err := utils.GetAzureResourceTypeApiVersion(resourceType)
@@ -93,6 +133,104 @@ func DataPlaneResourceIDWithResourceType(azureResourceId, resourceType string) (
 	return NewDataPlaneResourceId(name, parentId, resourceType)
 }
 
+// DataPlaneResourceID parses an import ID into an DataPlaneResourceId struct. The input is either in format `<resource-type>@<api-version>|<resource-id>`
+// or `<resource-id>?api-version=<api-version>`, in the latter case the resource type is inferred from the resource id.
+func DataPlaneResourceID(input string) (DataPlaneResourceId, error) {
+	if index := strings.Index(input, "|"); index != -1 {
+		resourceType := input[0:index]
+		azureResourceId := input[index+1:]
+		if azureResourceId == "" {
+			return DataPlaneResourceId{}, fmt.Errorf("ID was missing the 'azure resource id' element")
+		}
+		return DataPlaneResourceIDWithResourceType(azureResourceId, resourceType)
+	}
+
+	idUrl, err := url.Parse(input)
+	if err != nil {
+		return DataPlaneResourceId{}, err
+	}
+
+	azureResourceId := idUrl.Path
+	apiVersion := idUrl.Query().Get("api-version")
+
+	if azureResourceId == "" {
+		return DataPlaneResourceId{}, fmt.Errorf("ID was missing the 'azure resource id' element")
+	}
+
+	if apiVersion == "" {
+		return DataPlaneResourceId{}, fmt.Errorf("ID was missing the 'api-version' element")
+	}
+
+	azureResourceType, err := findResourceTypeByDataPlaneResourceId(azureResourceId)
+	if err != nil {
+		return DataPlaneResourceId{}, err
+	}
+
+	return DataPlaneResourceIDWithResourceType(azureResourceId, fmt.Sprintf("%s@%s", azureResourceType, apiVersion))
+}
+
+// findResourceTypeByDataPlaneResourceId returns the resource type whose url format matches the resource id,
+// the url format with the most matched static segments wins.
+func findResourceTypeByDataPlaneResourceId(azureResourceId string) (string, error) {
+	azureResourceIdParts := strings.Split(strings.Trim(azureResourceId, "/"), "/")
+	candidates := make([]string, 0)
+	bestScore := 0
+	for _, apiPath := range apiPaths {
+		score := matchUrlFormat(apiPath.UrlFormat, azureResourceIdParts)
+		switch {
+		case score == 0 || score < bestScore:
+			continue
+		case score > bestScore:
+			bestScore = score
+			candidates = []string{apiPath.ResourceType}
+		default:
+			candidates = append(candidates, apiPath.ResourceType)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("failed to find the resource type of %s, please specify the ID in format `<resource-type>@<api-version>|<resource-id>`", azureResourceId)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("the resource type of %s is ambiguous, possible values are [%s], please specify the ID in format `<resource-type>@<api-version>|<resource-id>`", azureResourceId, strings.Join(candidates, ", "))
+	}
+}
+
+// matchUrlFormat returns the number of matched static segments, or 0 if the resource id doesn't match the url format.
+func matchUrlFormat(urlFormat string, azureResourceIdParts []string) int {
+	urlFormatParts := strings.Split(urlFormat, "/")
+	score := 0
+	j := len(azureResourceIdParts) - 1
+	for i := len(urlFormatParts) - 1; i >= 0; i-- {
+		part := urlFormatParts[i]
+		if part == "{parentId}" {
+			if j < 0 {
+				return 0
+			}
+			return score
+		}
+		if j < 0 {
+			return 0
+		}
+		switch {
+		case strings.HasPrefix(part, "{name="):
+			if !strings.EqualFold(azureResourceIdParts[j], part[6:len(part)-1]) {
+				return 0
+			}
+			score++
+		case strings.HasPrefix(part, "{"):
+		case strings.EqualFold(azureResourceIdParts[j], part):
+			score++
+		default:
+			return 0
+		}
+		j--
+	}
+	return 0
+}
+
 func (id DataPlaneResourceId) String() string {
 	segments := []string{
 		fmt.Sprintf("ResourceId %q", id.AzureResourceId),
@@ -173,3 +173,168 @@ func Test_DataPlaneResourceIDWithResourceType(t *testing.T) {
 		}
 	}
 }
+
+func Test_DataPlaneResourceID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *parse.DataPlaneResourceId
+	}{
+		{
+			Input: "xxx.azconfig.io/kv/test?api-version=1.0",
+			Error: false,
+			Expected: &parse.DataPlaneResourceId{
+				AzureResourceId:   "xxx.azconfig.io/kv/test",
+				ApiVersion:        "1.0",
+				AzureResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+				ParentId:          "xxx.azconfig.io",
+				Name:              "test",
+			},
+		},
+		{
+			Input: "xxx.xxx.xxx/api/devices/test/attestation?api-version=8.2",
+			Error: false,
+			Expected: &parse.DataPlaneResourceId{
+				AzureResourceId:   "xxx.xxx.xxx/api/devices/test/attestation",
+				ApiVersion:        "8.2",
+				AzureResourceType: "Microsoft.IoTCentral/iotApps/devices/attestation",
+				ParentId:          "xxx.xxx.xxx",
+				Name:              "test",
+			},
+		},
+		{
+			Input: "foo.keyvault.azure.net/certificates/contacts?api-version=7.4",
+			Error: false,
+			Expected: &parse.DataPlaneResourceId{
+				AzureResourceId:   "foo.keyvault.azure.net/certificates/contacts",
+				ApiVersion:        "7.4",
+				AzureResourceType: "Microsoft.KeyVault/vaults/certificates/contacts",
+				ParentId:          "foo.keyvault.azure.net",
+				Name:              "",
+			},
+		},
+		{
+			Input: "Microsoft.DeviceUpdate/accounts/v2/groups@8.2|xxx.xxx.xxx/v2/management/groups/test",
+			Error: false,
+			Expected: &parse.DataPlaneResourceId{
+				AzureResourceId:   "xxx.xxx.xxx/v2/management/groups/test",
+				ApiVersion:        "8.2",
+				AzureResourceType: "Microsoft.DeviceUpdate/accounts/v2/groups",
+				ParentId:          "xxx.xxx.xxx",
+				Name:              "test",
+			},
+		},
+		{
+			// missing api-version
+			Input: "xxx.azconfig.io/kv/test",
+			Error: true,
+		},
+		{
+			// unknown url format
+			Input: "xxx.azconfig.io/unknown/test?api-version=1.0",
+			Error: true,
+		},
+		{
+			// missing resource id
+			Input: "Microsoft.AppConfiguration/configurationStores/keyValues@1.0|",
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parse.DataPlaneResourceID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AzureResourceId != v.Expected.AzureResourceId {
+			t.Fatalf("Expected %q but got %q for AzureResourceId", v.Expected.AzureResourceId, actual.AzureResourceId)
+		}
+		if actual.ApiVersion != v.Expected.ApiVersion {
+			t.Fatalf("Expected %q but got %q for ApiVersion", v.Expected.ApiVersion, actual.ApiVersion)
+		}
+		if actual.AzureResourceType != v.Expected.AzureResourceType {
+			t.Fatalf("Expected %q but got %q for AzureResourceType", v.Expected.AzureResourceType, actual.AzureResourceType)
+		}
+		if actual.ParentId != v.Expected.ParentId {
+			t.Fatalf("Expected %q but got %q for ParentId", v.Expected.ParentId, actual.ParentId)
+		}
+		if actual.Name != v.Expected.Name {
+			t.Fatalf("Expected %q but got %q for Name", v.Expected.Name, actual.Name)
+		}
+	}
+}
+
+func Test_NewDataPlaneCollectionId(t *testing.T) {
+	testData := []struct {
+		ParentId     string
+		ResourceType string
+		Error        bool
+		Expected     string
+	}{
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues@api-version",
+			Expected:     "xxx.xxx.xxx/kv",
+		},
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.DeviceUpdate/accounts/v2/groups@8.2",
+			Expected:     "xxx.xxx.xxx/v2/management/groups",
+		},
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.IoTCentral/iotApps/apiTokens@8.2",
+			Expected:     "xxx.xxx.xxx/api/apiTokens",
+		},
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.IoTCentral/iotApps/devices/attestation@8.2",
+			Error:        true,
+		},
+		{
+			ParentId:     "foo.keyvault.azure.net",
+			ResourceType: "Microsoft.KeyVault/vaults/certificates/contacts@v1.1-preview.2",
+			Error:        true,
+		},
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.Purview/accounts/Account/resourceSetRuleConfigs@2023-09-01",
+			Error:        true,
+		},
+		{
+			ParentId:     "xxx.xxx.xxx",
+			ResourceType: "Microsoft.Unknown/unknown@8.2",
+			Error:        true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q %q", v.ParentId, v.ResourceType)
+
+		actual, err := parse.NewDataPlaneCollectionId(v.ParentId, v.ResourceType)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.AzureResourceId != v.Expected {
+			t.Fatalf("Expected %q but got %q for AzureResourceId", v.Expected, actual.AzureResourceId)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package preflight
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+)
+
+// DataPlaneValidator validates the data plane resource by calling the validation endpoint of the service,
+// or by checking the limits documented by the service if it doesn't provide a validation endpoint.
+type DataPlaneValidator func(ctx context.Context, client clients.DataPlaneRequester, id parse.DataPlaneResourceId, body interface{}) error
+
+var (
+	dataPlaneValidatorsLock sync.RWMutex
+	dataPlaneValidators     = map[string]DataPlaneValidator{
+		"microsoft.appconfiguration/configurationstores/keyvalues": validateAppConfigurationKeyValue,
+	}
+)
+
+// RegisterDataPlaneValidator registers the validator of the data plane resource type, the resource type doesn't contain the api-version,
+// e.g. `Microsoft.AppConfiguration/configurationStores/keyValues`. The registered validator of the same resource type is replaced.
+func RegisterDataPlaneValidator(resourceType string, validator DataPlaneValidator) {
+	dataPlaneValidatorsLock.Lock()
+	defer dataPlaneValidatorsLock.Unlock()
+	dataPlaneValidators[strings.ToLower(resourceType)] = validator
+}
+
+func dataPlaneValidator(resourceType string) DataPlaneValidator {
+	dataPlaneValidatorsLock.RLock()
+	defer dataPlaneValidatorsLock.RUnlock()
+	return dataPlaneValidators[strings.ToLower(resourceType)]
+}
+
+// IsDataPlaneSupported checks if there's a validator registered for the data plane resource type
+func IsDataPlaneSupported(resourceType string) bool {
+	return dataPlaneValidator(resourceType) != nil
+}
+
+// ValidateDataPlane validates the data plane resource using the validator registered for its resource type,
+// the resource is skipped if there's no validator registered.
+func ValidateDataPlane(ctx context.Context, client clients.DataPlaneRequester, id parse.DataPlaneResourceId, body interface{}) error {
+	validator := dataPlaneValidator(id.AzureResourceType)
+	if validator == nil {
+		return nil
+	}
+	return validator(ctx, client, id, body)
+}
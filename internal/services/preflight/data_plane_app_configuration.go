@@ -0,0 +1,46 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+)
+
+// appConfigurationKeyValueMaxSize is the combined size limit of the key, the value and the attributes of a key-value
+const appConfigurationKeyValueMaxSize = 10 * 1024
+
+// validateAppConfigurationKeyValue validates the App Configuration key-value against the limits documented by the service,
+// the service doesn't provide a validation endpoint, so the checks are done locally.
+func validateAppConfigurationKeyValue(_ context.Context, _ clients.DataPlaneRequester, id parse.DataPlaneResourceId, body interface{}) error {
+	key := id.Name
+	if key == "." || key == ".." {
+		return fmt.Errorf("the key %q of the App Configuration key-value is invalid, the key can't be `.` or `..`", key)
+	}
+	if strings.Contains(key, "%") {
+		return fmt.Errorf("the key %q of the App Configuration key-value is invalid, the key can't contain the `%%` character", key)
+	}
+
+	size := len(key)
+	if bodyMap, ok := body.(map[string]interface{}); ok {
+		for _, field := range []string{"value", "content_type", "label"} {
+			if value, ok := bodyMap[field].(string); ok {
+				size += len(value)
+			}
+		}
+		if tags, ok := bodyMap["tags"].(map[string]interface{}); ok {
+			for tagKey, tagValue := range tags {
+				size += len(tagKey)
+				if value, ok := tagValue.(string); ok {
+					size += len(value)
+				}
+			}
+		}
+	}
+	if size > appConfigurationKeyValueMaxSize {
+		return fmt.Errorf("the size of the App Configuration key-value %q is %d bytes, which exceeds the limit of %d bytes including the key, the value and all the attributes", key, size, appConfigurationKeyValueMaxSize)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+)
+
+func Test_ValidateDataPlane(t *testing.T) {
+	resourceType := "Microsoft.KeyVault/vaults/storage"
+	id, err := parse.NewDataPlaneResourceId("account", "myvault.vault.azure.net", resourceType+"@7.4")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	if IsDataPlaneSupported(resourceType) {
+		t.Fatalf("Expected %s not to be supported before registering the validator", resourceType)
+	}
+	if err := ValidateDataPlane(context.Background(), nil, id, nil); err != nil {
+		t.Fatalf("Expected the resource without validator to be skipped, but got %v", err)
+	}
+
+	var validatedId parse.DataPlaneResourceId
+	RegisterDataPlaneValidator("microsoft.keyvault/vaults/storage", func(ctx context.Context, client clients.DataPlaneRequester, id parse.DataPlaneResourceId, body interface{}) error {
+		validatedId = id
+		return errors.New("invalid value")
+	})
+	defer func() {
+		dataPlaneValidatorsLock.Lock()
+		delete(dataPlaneValidators, "microsoft.keyvault/vaults/storage")
+		dataPlaneValidatorsLock.Unlock()
+	}()
+
+	if !IsDataPlaneSupported(resourceType) {
+		t.Fatalf("Expected %s to be supported after registering the validator", resourceType)
+	}
+	if err := ValidateDataPlane(context.Background(), nil, id, map[string]interface{}{}); err == nil || err.Error() != "invalid value" {
+		t.Fatalf("Expected the error of the validator, but got %v", err)
+	}
+	if validatedId.AzureResourceId != id.AzureResourceId {
+		t.Fatalf("Expected the validator to be called with %s, but got %s", id.AzureResourceId, validatedId.AzureResourceId)
+	}
+}
+
+func Test_ValidateDataPlaneAppConfigurationKeyValue(t *testing.T) {
+	resourceType := "Microsoft.AppConfiguration/configurationStores/keyValues"
+	if !IsDataPlaneSupported(resourceType) {
+		t.Fatalf("Expected %s to be supported", resourceType)
+	}
+
+	testcases := []struct {
+		Key         string
+		Body        interface{}
+		ExpectError bool
+	}{
+		{
+			Key: "myapp:settings:color",
+			Body: map[string]interface{}{
+				"value":        "blue",
+				"content_type": "text/plain",
+				"tags": map[string]interface{}{
+					"env": "test",
+				},
+			},
+			ExpectError: false,
+		},
+		{
+			Key:         "..",
+			Body:        map[string]interface{}{},
+			ExpectError: true,
+		},
+		{
+			Key:         "100%",
+			Body:        map[string]interface{}{},
+			ExpectError: true,
+		},
+		{
+			Key: "large",
+			Body: map[string]interface{}{
+				"value": strings.Repeat("a", 10*1024),
+			},
+			ExpectError: true,
+		},
+		{
+			Key: "large-tags",
+			Body: map[string]interface{}{
+				"value": strings.Repeat("a", 6*1024),
+				"tags": map[string]interface{}{
+					"description": strings.Repeat("b", 5*1024),
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Logf("[DEBUG] Testing key: %s", testcase.Key)
+		id, err := parse.NewDataPlaneResourceId(testcase.Key, "mystore.azconfig.io", resourceType+"@1.0")
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		err = ValidateDataPlane(context.Background(), nil, id, testcase.Body)
+		if testcase.ExpectError != (err != nil) {
+			t.Fatalf("Expected error %v, but got %v", testcase.ExpectError, err)
+		}
+	}
+}
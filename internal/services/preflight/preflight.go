@@ -19,6 +19,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 )
 
+// unknownPlaceholder is the template expression which replaces the unknown values in the body
+const unknownPlaceholder = "[length('foo')]"
+
 type RequestBodyModel struct {
 	Provider  string                   `json:"provider"`
 	Type      string                   `json:"type"`
@@ -55,32 +58,70 @@ func NamePlaceholder() string {
 	return acctest.RandStringFromCharSet(8, acctest.CharSetAlpha)
 }
 
+// ChildResourceParentIdPlaceholder generates a placeholder for the parentID of a child resource whose parent will be created later.
+// The names of the parent resources are faked, and the scope is faked based on the resource definition of the top-level resource type.
+func ChildResourceParentIdPlaceholder(azureResourceType string, apiVersion string, subscriptionId string) (string, error) {
+	resourceProvider, parts, err := utils.GetAzureResourceTypeParts(azureResourceType)
+	if err != nil {
+		return "", err
+	}
+	if len(parts) < 2 {
+		return "", fmt.Errorf("failed to generate parentID placeholder because %s is not a child resource type", azureResourceType)
+	}
+
+	scopeId, err := ParentIdPlaceholder(topLevelResourceDefinition(resourceProvider+"/"+parts[0], apiVersion), subscriptionId)
+	if err != nil {
+		return "", err
+	}
+
+	parentId := fmt.Sprintf("%s/providers/%s/%s/%s", strings.TrimSuffix(scopeId, "/"), resourceProvider, parts[0], NamePlaceholder())
+	for _, part := range parts[1 : len(parts)-1] {
+		parentId = fmt.Sprintf("%s/%s/%s", parentId, part, NamePlaceholder())
+	}
+	return parentId, nil
+}
+
+// topLevelResourceDefinition returns the resource definition of the top-level resource type, it falls back to the latest api-version
+// if the top-level resource type doesn't have the same api-version as its child resource type.
+func topLevelResourceDefinition(resourceType string, apiVersion string) *aztypes.ResourceType {
+	if resourceDef, err := azure.GetResourceDefinition(resourceType, apiVersion); err == nil {
+		return resourceDef
+	}
+	apiVersions := azure.GetApiVersions(resourceType)
+	if len(apiVersions) == 0 {
+		return nil
+	}
+	resourceDef, _ := azure.GetResourceDefinition(resourceType, apiVersions[len(apiVersions)-1])
+	return resourceDef
+}
+
 // IsSupported checks if the resource type is supported for preflight validation
-// The resource type should be a top-level resource type, and the specified parentID should be a resource group, subscription, tenant or management group
-// If the parentID is not specified, the resource type should be able to deploy only at the tenant, management group, subscription or resource group level
+// The specified parentID should be a resource group, subscription, tenant or management group, or a parent resource deployed at one of them
+// If the parentID is not specified, the resource type, or the top-level resource type of a child resource type, should be able to deploy only at the tenant, management group, subscription or resource group level
 func IsSupported(resourceType string, parentId string) bool {
 	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceType)
 	if err != nil {
 		return false
 	}
 
-	if !utils.IsTopLevelResourceType(azureResourceType) {
-		return false
-	}
-
-	// if the parentID is specified, it should be a resource group, subscription, tenant or management group
 	if parentId != "" {
-		parentResourceType := utils.GetResourceType(parentId)
-		return strings.EqualFold(arm.ResourceGroupResourceType.String(), parentResourceType) ||
-			strings.EqualFold(arm.SubscriptionResourceType.String(), parentResourceType) ||
-			strings.EqualFold(arm.TenantResourceType.String(), parentResourceType) ||
-			strings.EqualFold("Microsoft.Management/managementGroups", parentResourceType)
+		scope, _, err := parseParentId(azureResourceType, parentId)
+		return err == nil && isDeploymentScope(scope)
 	}
 
 	// if the parentID is not specified, the resource type should be able to deploy only at the tenant, management group, subscription or resource group level
 
-	resourceDef, err := azure.GetResourceDefinition(azureResourceType, apiVersion)
-	if err != nil || resourceDef == nil || len(resourceDef.ScopeTypes) != 1 {
+	var resourceDef *aztypes.ResourceType
+	if utils.IsTopLevelResourceType(azureResourceType) {
+		resourceDef, _ = azure.GetResourceDefinition(azureResourceType, apiVersion)
+	} else {
+		resourceProvider, parts, err := utils.GetAzureResourceTypeParts(azureResourceType)
+		if err != nil {
+			return false
+		}
+		resourceDef = topLevelResourceDefinition(resourceProvider+"/"+parts[0], apiVersion)
+	}
+	if resourceDef == nil || len(resourceDef.ScopeTypes) != 1 {
 		return false
 	}
 
@@ -90,29 +131,43 @@ func IsSupported(resourceType string, parentId string) bool {
 		deployedScope == aztypes.Subscription || deployedScope == aztypes.ResourceGroup
 }
 
-// Validate validates the resource using the preflight API
-func Validate(ctx context.Context, client *clients.ResourceClient, resourceType string, parentId string, name string, location string, body types.Dynamic, identity types.List) error {
+// Validate validates the resource using the preflight API, the sensitive body is merged into the body before the validation
+// For child resources, the resource is validated with the nested name, e.g. `vnet/subnet`, at the scope of its top-level parent resource
+func Validate(ctx context.Context, client *clients.ResourceClient, resourceType string, parentId string, name string, location string, body types.Dynamic, sensitiveBody types.Dynamic, identity types.List) error {
+	resource := make(map[string]interface{})
+	err := unmarshalPreflightBody(body, sensitiveBody, identity, &resource)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Skipping preflight validation for resource %s because the body is invalid: %v", resourceType, err))
+		return nil
+	}
+
+	return ValidateObject(ctx, client, resourceType, parentId, name, location, resource)
+}
+
+// ValidateObject validates the resource body which is already expanded using the preflight API, e.g. the merged body of an update resource
+func ValidateObject(ctx context.Context, client *clients.ResourceClient, resourceType string, parentId string, name string, location string, resource map[string]interface{}) error {
 	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceType)
 	if err != nil {
 		return err
 	}
 
+	scope, parentNames, err := parseParentId(azureResourceType, parentId)
+	if err != nil {
+		return err
+	}
+
 	payload := RequestBodyModel{}
 	payload.Provider, payload.Type, _ = strings.Cut(azureResourceType, "/")
-	payload.Scope = parentId
+	payload.Scope = scope
 	if location != "" {
 		payload.Location = location
 	}
 
-	resource := make(map[string]interface{})
-	err = unmarshalPreflightBody(body, identity, &resource)
-	if err != nil {
-		tflog.Warn(ctx, fmt.Sprintf("Skipping preflight validation for resource %s because the body is invalid: %v", resourceType, err))
-		return nil
-	}
-
-	resource["name"] = name
+	resource["name"] = strings.Join(append(parentNames, name), "/")
 	resource["apiVersion"] = apiVersion
+	if len(parentNames) != 0 {
+		resource["type"] = azureResourceType
+	}
 
 	payload.Resources = []map[string]interface{}{resource}
 
@@ -120,17 +175,79 @@ func Validate(ctx context.Context, client *clients.ResourceClient, resourceType
 	return err
 }
 
-func unmarshalPreflightBody(input types.Dynamic, identityList types.List, out *map[string]interface{}) error {
-	if input.IsNull() || input.IsUnknown() || input.IsUnderlyingValueUnknown() {
-		return fmt.Errorf("input is null or unknown")
+// parseParentId splits the parentID into the scope which the top-level resource is deployed to and the names of the parent resources.
+// For top-level resource types, the scope is the parentID and there's no parent resource names.
+func parseParentId(azureResourceType string, parentId string) (string, []string, error) {
+	parentType := utils.GetParentType(azureResourceType)
+	if parentType == "" {
+		return parentId, nil, nil
 	}
 
-	const unknownPlaceholder = "[length('foo')]"
+	id, err := arm.ParseResourceID(parentId)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing parent ID %q: %+v", parentId, err)
+	}
+	_, parts, err := utils.GetAzureResourceTypeParts(azureResourceType)
+	if err != nil {
+		return "", nil, err
+	}
+	names := make([]string, len(parts)-1)
+	for i := len(names) - 1; i >= 0; i-- {
+		if id == nil || !strings.EqualFold(id.ResourceType.String(), parentType) {
+			return "", nil, fmt.Errorf("the parent ID %q doesn't match the resource type %s", parentId, azureResourceType)
+		}
+		names[i] = id.Name
+		id = id.Parent
+		parentType = utils.GetParentType(parentType)
+	}
+	if id == nil {
+		return "", nil, fmt.Errorf("failed to find the scope of the parent ID %q", parentId)
+	}
+	if id.ResourceType.String() == arm.TenantResourceType.String() {
+		return "/", names, nil
+	}
+	return id.String(), names, nil
+}
+
+// isDeploymentScope checks if the ID is a resource group, subscription, tenant or management group
+func isDeploymentScope(id string) bool {
+	resourceType := utils.GetResourceType(id)
+	return strings.EqualFold(arm.ResourceGroupResourceType.String(), resourceType) ||
+		strings.EqualFold(arm.SubscriptionResourceType.String(), resourceType) ||
+		strings.EqualFold(arm.TenantResourceType.String(), resourceType) ||
+		strings.EqualFold("Microsoft.Management/managementGroups", resourceType)
+}
 
-	data, err := dynamic.ToJSONWithUnknownValueHandler(input, func(value attr.Value) ([]byte, error) {
-		return json.Marshal(unknownPlaceholder)
-	})
+func unknownValueHandler(value attr.Value) ([]byte, error) {
+	return json.Marshal(unknownPlaceholder)
+}
 
+// unmarshalPreflightSensitiveBody unmarshals the sensitive body, the unknown values are replaced with the placeholder.
+// It returns nil if the sensitive body is null.
+func unmarshalPreflightSensitiveBody(sensitiveInput types.Dynamic) (interface{}, error) {
+	if sensitiveInput.IsUnknown() || sensitiveInput.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("sensitive input is unknown")
+	}
+	if sensitiveInput.IsNull() {
+		return nil, nil
+	}
+	data, err := dynamic.ToJSONWithUnknownValueHandler(sensitiveInput, unknownValueHandler)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling sensitive input failed: %v", err)
+	}
+	var out interface{}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf(`unmarshaling sensitive input failed: %+v`, err)
+	}
+	return out, nil
+}
+
+func unmarshalPreflightBody(input types.Dynamic, sensitiveInput types.Dynamic, identityList types.List, out *map[string]interface{}) error {
+	if input.IsNull() || input.IsUnknown() || input.IsUnderlyingValueUnknown() {
+		return fmt.Errorf("input is null or unknown")
+	}
+
+	data, err := dynamic.ToJSONWithUnknownValueHandler(input, unknownValueHandler)
 	if err != nil {
 		return fmt.Errorf("marshaling failed: %v", err)
 	}
@@ -139,6 +256,19 @@ func unmarshalPreflightBody(input types.Dynamic, identityList types.List, out *m
 		return fmt.Errorf(`unmarshaling failed: value: %s, err: %+v`, string(data), err)
 	}
 
+	// the sensitive body is merged into the body in the same way as the request body
+	sensitiveValue, err := unmarshalPreflightSensitiveBody(sensitiveInput)
+	if err != nil {
+		return err
+	}
+	if sensitiveValue != nil {
+		merged, ok := utils.MergeObject(*out, sensitiveValue).(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("the sensitive input must be an object")
+		}
+		*out = merged
+	}
+
 	if out == nil {
 		out = &map[string]interface{}{}
 	}
@@ -1,10 +1,13 @@
 package preflight
 
 import (
+	"reflect"
 	"regexp"
 	"testing"
 
 	aztypes "github.com/Azure/terraform-provider-azapi/internal/azure/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func Test_ParentIdPlaceholder(t *testing.T) {
@@ -123,9 +126,29 @@ func Test_IsSupported(t *testing.T) {
 		},
 
 		{
-			// resource is not top level resource
+			// the parent resource will be created later
 			ParentId:     "",
 			ResourceType: "Microsoft.Network/virtualNetworks/subnets@2020-06-01",
+			Expected:     true,
+		},
+
+		{
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/azapifakerg/providers/Microsoft.Network/virtualNetworks/vnet",
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets@2020-06-01",
+			Expected:     true,
+		},
+
+		{
+			// parent resource type mismatch
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/azapifakerg",
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets@2020-06-01",
+			Expected:     false,
+		},
+
+		{
+			// extension resource
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/azapifakerg/providers/Microsoft.Network/virtualNetworks/vnet",
+			ResourceType: "Microsoft.Authorization/locks@2020-05-01",
 			Expected:     false,
 		},
 
@@ -174,3 +197,160 @@ func Test_IsSupported(t *testing.T) {
 		}
 	}
 }
+
+func Test_ParseParentId(t *testing.T) {
+	testcases := []struct {
+		ResourceType  string
+		ParentId      string
+		ExpectedScope string
+		ExpectedNames []string
+		ExpectedErr   bool
+	}{
+		{
+			ResourceType:  "Microsoft.Network/virtualNetworks",
+			ParentId:      "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedScope: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedNames: nil,
+		},
+
+		{
+			ResourceType:  "Microsoft.Network/virtualNetworks/subnets",
+			ParentId:      "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet",
+			ExpectedScope: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedNames: []string{"vnet"},
+		},
+
+		{
+			ResourceType:  "Microsoft.Sql/servers/databases/backupShortTermRetentionPolicies",
+			ParentId:      "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Sql/servers/server/databases/db",
+			ExpectedScope: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedNames: []string{"server", "db"},
+		},
+
+		{
+			ResourceType:  "Microsoft.Management/managementGroups/settings",
+			ParentId:      "/providers/Microsoft.Management/managementGroups/mg",
+			ExpectedScope: "/",
+			ExpectedNames: []string{"mg"},
+		},
+
+		{
+			ResourceType: "Microsoft.Network/virtualNetworks/subnets",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedErr:  true,
+		},
+
+		{
+			ResourceType: "Microsoft.Sql/servers/databases/backupShortTermRetentionPolicies",
+			ParentId:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Sql/servers/server",
+			ExpectedErr:  true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		scope, names, err := parseParentId(testcase.ResourceType, testcase.ParentId)
+		if testcase.ExpectedErr {
+			if err == nil {
+				t.Errorf("Expected error, but got nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+			continue
+		}
+		if scope != testcase.ExpectedScope {
+			t.Errorf("Expected scope %s, but got %s", testcase.ExpectedScope, scope)
+		}
+		if !reflect.DeepEqual(names, testcase.ExpectedNames) {
+			t.Errorf("Expected names %v, but got %v", testcase.ExpectedNames, names)
+		}
+	}
+}
+
+func Test_UnmarshalPreflightBody(t *testing.T) {
+	body := types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{
+		"properties": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"administratorLogin": types.StringType,
+		}},
+	}, map[string]attr.Value{
+		"properties": types.ObjectValueMust(map[string]attr.Type{
+			"administratorLogin": types.StringType,
+		}, map[string]attr.Value{
+			"administratorLogin": types.StringValue("admin"),
+		}),
+	}))
+	sensitiveBodyType := map[string]attr.Type{
+		"administratorLoginPassword": types.StringType,
+	}
+
+	testcases := []struct {
+		SensitiveBody types.Dynamic
+		Expected      map[string]interface{}
+		ExpectedErr   bool
+	}{
+		{
+			SensitiveBody: types.DynamicNull(),
+			Expected: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"administratorLogin": "admin",
+				},
+			},
+		},
+
+		{
+			SensitiveBody: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{
+				"properties": types.ObjectType{AttrTypes: sensitiveBodyType},
+			}, map[string]attr.Value{
+				"properties": types.ObjectValueMust(sensitiveBodyType, map[string]attr.Value{
+					"administratorLoginPassword": types.StringValue("secret"),
+				}),
+			})),
+			Expected: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"administratorLogin":         "admin",
+					"administratorLoginPassword": "secret",
+				},
+			},
+		},
+
+		{
+			SensitiveBody: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{
+				"properties": types.ObjectType{AttrTypes: sensitiveBodyType},
+			}, map[string]attr.Value{
+				"properties": types.ObjectValueMust(sensitiveBodyType, map[string]attr.Value{
+					"administratorLoginPassword": types.StringUnknown(),
+				}),
+			})),
+			Expected: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"administratorLogin":         "admin",
+					"administratorLoginPassword": "[length('foo')]",
+				},
+			},
+		},
+
+		{
+			SensitiveBody: types.DynamicUnknown(),
+			ExpectedErr:   true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		out := make(map[string]interface{})
+		err := unmarshalPreflightBody(body, testcase.SensitiveBody, types.ListNull(types.ObjectType{}), &out)
+		if testcase.ExpectedErr {
+			if err == nil {
+				t.Errorf("Expected error, but got nil")
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(out, testcase.Expected) {
+			t.Errorf("Expected %v, but got %v", testcase.Expected, out)
+		}
+	}
+}
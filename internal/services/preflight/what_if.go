@@ -0,0 +1,308 @@
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/terraform-provider-azapi/internal/azure/tags"
+	"github.com/Azure/terraform-provider-azapi/internal/clients"
+	"github.com/Azure/terraform-provider-azapi/utils"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const whatIfApiVersion = "2021-04-01"
+
+// WhatIfChange is the predicted change of a resource returned by the deployments what-if API
+type WhatIfChange struct {
+	ResourceId string                 `json:"resourceId"`
+	ChangeType string                 `json:"changeType"`
+	Delta      []WhatIfPropertyChange `json:"delta"`
+}
+
+// WhatIfPropertyChange is the predicted change of a property returned by the deployments what-if API
+type WhatIfPropertyChange struct {
+	Path               string                 `json:"path"`
+	PropertyChangeType string                 `json:"propertyChangeType"`
+	Before             interface{}            `json:"before"`
+	After              interface{}            `json:"after"`
+	Children           []WhatIfPropertyChange `json:"children"`
+}
+
+type whatIfResponseModel struct {
+	Properties struct {
+		Changes []WhatIfChange `json:"changes"`
+	} `json:"properties"`
+}
+
+// WhatIf predicts the changes of the resource using the deployments what-if API, the deployment is submitted at the scope of the parentId,
+// or the scope of the top-level parent resource for child resources. The deployments which are not submitted to a resource group need a location
+// to store the deployment data, the resource location or the defaultLocation is used, and the what-if is skipped if neither is specified.
+// The sensitive body is merged into the body, and the unknown values are replaced with placeholders in the same way as the preflight validation.
+// The changes of the sensitive values are redacted in the returned changes.
+func WhatIf(ctx context.Context, client *clients.ResourceClient, resourceType string, parentId string, name string, location string, defaultLocation string, tagsValue types.Map, body types.Dynamic, sensitiveBody types.Dynamic, identity types.List) ([]WhatIfChange, error) {
+	azureResourceType, apiVersion, err := utils.GetAzureResourceTypeApiVersion(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, parentNames, err := parseParentId(azureResourceType, parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := make(map[string]interface{})
+	err = unmarshalPreflightBody(body, sensitiveBody, identity, &resource)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Skipping what-if for resource %s because the body is invalid: %v", resourceType, err))
+		return nil, nil
+	}
+
+	// the values replaced with placeholders are not the values to deploy, their predicted changes are skipped
+	skippedPaths := placeholderPaths(resource, "")
+
+	// the predicted changes of the sensitive values are redacted, because they're reported as warnings in the plan
+	sensitiveValue, err := unmarshalPreflightSensitiveBody(sensitiveBody)
+	if err != nil {
+		return nil, err
+	}
+	sensitivePaths := leafPaths(sensitiveValue, "", func(value interface{}) bool {
+		return value != unknownPlaceholder
+	})
+
+	resource["type"] = azureResourceType
+	resource["apiVersion"] = apiVersion
+	resource["name"] = strings.Join(append(parentNames, name), "/")
+	if resource["location"] == nil && location != "" {
+		resource["location"] = location
+	}
+	if resource["tags"] == nil && !tagsValue.IsNull() && !tagsValue.IsUnknown() && len(tagsValue.Elements()) != 0 {
+		resource["tags"] = tags.ExpandTags(tagsValue)
+	}
+
+	deploymentId, templateSchema, err := whatIfDeploymentScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]interface{}{
+		"mode": "Incremental",
+		"template": map[string]interface{}{
+			"$schema":        templateSchema,
+			"contentVersion": "1.0.0.0",
+			"resources":      []interface{}{resource},
+		},
+	}
+	payload := map[string]interface{}{
+		"properties": properties,
+	}
+	if !strings.EqualFold(utils.GetResourceType(scope), arm.ResourceGroupResourceType.String()) {
+		deploymentLocation := location
+		if deploymentLocation == "" {
+			deploymentLocation = defaultLocation
+		}
+		if deploymentLocation == "" {
+			tflog.Warn(ctx, fmt.Sprintf("Skipping what-if for resource %s because the deployment location can't be determined, please specify the `location` or the `default_location` in the provider block", resourceType))
+			return nil, nil
+		}
+		payload["location"] = deploymentLocation
+	}
+
+	responseBody, err := client.Action(ctx, deploymentId, "whatIf", whatIfApiVersion, "POST", payload, clients.DefaultRequestOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(responseBody)
+	if err != nil {
+		return nil, err
+	}
+	var response whatIfResponseModel
+	if err = json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("unmarshaling the what-if response: %+v", err)
+	}
+	changes := response.Properties.Changes
+	for i := range changes {
+		changes[i].Delta = skipWhatIfPropertyChanges(changes[i].Delta, "", skippedPaths)
+		changes[i].Delta = redactWhatIfPropertyChanges(changes[i].Delta, "", sensitivePaths)
+	}
+	return changes, nil
+}
+
+// placeholderPaths returns the paths of the values which are replaced with the placeholder, in the same format as the formatted changes,
+// e.g. `properties.subnets[0].name`.
+func placeholderPaths(input interface{}, path string) []string {
+	return leafPaths(input, path, func(value interface{}) bool {
+		return value == unknownPlaceholder
+	})
+}
+
+// leafPaths returns the paths of the values which are neither objects nor arrays and match the filter
+func leafPaths(input interface{}, path string, filter func(interface{}) bool) []string {
+	paths := make([]string, 0)
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			propertyPath := key
+			if path != "" {
+				propertyPath = path + "." + key
+			}
+			paths = append(paths, leafPaths(value, propertyPath, filter)...)
+		}
+	case []interface{}:
+		for index, value := range v {
+			paths = append(paths, leafPaths(value, fmt.Sprintf("%s[%d]", path, index), filter)...)
+		}
+	default:
+		if filter(v) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// redactWhatIfPropertyChanges replaces the values of the changes at or under the sensitive paths, and the values which contain the sensitive paths.
+func redactWhatIfPropertyChanges(changes []WhatIfPropertyChange, parentPath string, sensitivePaths []string) []WhatIfPropertyChange {
+	if len(sensitivePaths) == 0 {
+		return changes
+	}
+	for i := range changes {
+		propertyPath := whatIfPropertyPath(parentPath, changes[i].Path)
+		if len(changes[i].Children) != 0 {
+			changes[i].Children = redactWhatIfPropertyChanges(changes[i].Children, propertyPath, sensitivePaths)
+			continue
+		}
+		for _, sensitivePath := range sensitivePaths {
+			if isSameOrNestedPath(propertyPath, sensitivePath) || isSameOrNestedPath(sensitivePath, propertyPath) {
+				if changes[i].Before != nil {
+					changes[i].Before = redactedValue{}
+				}
+				if changes[i].After != nil {
+					changes[i].After = redactedValue{}
+				}
+				break
+			}
+		}
+	}
+	return changes
+}
+
+// skipWhatIfPropertyChanges removes the changes at or under the skipped paths, and the changes whose values contain the skipped paths.
+func skipWhatIfPropertyChanges(changes []WhatIfPropertyChange, parentPath string, skippedPaths []string) []WhatIfPropertyChange {
+	if len(skippedPaths) == 0 {
+		return changes
+	}
+	out := make([]WhatIfPropertyChange, 0, len(changes))
+	for _, change := range changes {
+		propertyPath := whatIfPropertyPath(parentPath, change.Path)
+		if len(change.Children) != 0 {
+			change.Children = skipWhatIfPropertyChanges(change.Children, propertyPath, skippedPaths)
+			if len(change.Children) != 0 {
+				out = append(out, change)
+			}
+			continue
+		}
+		skipped := false
+		for _, skippedPath := range skippedPaths {
+			if isSameOrNestedPath(propertyPath, skippedPath) || isSameOrNestedPath(skippedPath, propertyPath) {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			out = append(out, change)
+		}
+	}
+	return out
+}
+
+// isSameOrNestedPath checks if the path is the same as the parent path or nested under it
+func isSameOrNestedPath(path string, parentPath string) bool {
+	return path == parentPath || strings.HasPrefix(path, parentPath+".") || strings.HasPrefix(path, parentPath+"[")
+}
+
+// whatIfPropertyPath returns the full path of the property change, the children of an array are identified by their indexes
+func whatIfPropertyPath(parentPath string, path string) string {
+	if parentPath == "" {
+		return path
+	}
+	if _, err := strconv.Atoi(path); err == nil {
+		return fmt.Sprintf("%s[%s]", parentPath, path)
+	}
+	return parentPath + "." + path
+}
+
+// whatIfDeploymentScope returns the ID of the deployment which is used to submit the what-if request and the template schema of the scope
+func whatIfDeploymentScope(scope string) (string, string, error) {
+	deploymentName := "azapi-what-if-" + NamePlaceholder()
+	scopeType := utils.GetResourceType(scope)
+	switch {
+	case strings.EqualFold(arm.ResourceGroupResourceType.String(), scopeType):
+		return fmt.Sprintf("%s/providers/Microsoft.Resources/deployments/%s", scope, deploymentName),
+			"https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#", nil
+	case strings.EqualFold(arm.SubscriptionResourceType.String(), scopeType):
+		return fmt.Sprintf("%s/providers/Microsoft.Resources/deployments/%s", scope, deploymentName),
+			"https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#", nil
+	case strings.EqualFold("Microsoft.Management/managementGroups", scopeType):
+		return fmt.Sprintf("%s/providers/Microsoft.Resources/deployments/%s", scope, deploymentName),
+			"https://schema.management.azure.com/schemas/2019-08-01/managementGroupDeploymentTemplate.json#", nil
+	case strings.EqualFold(arm.TenantResourceType.String(), scopeType):
+		return fmt.Sprintf("/providers/Microsoft.Resources/deployments/%s", deploymentName),
+			"https://schema.management.azure.com/schemas/2019-08-01/tenantDeploymentTemplate.json#", nil
+	}
+	return "", "", fmt.Errorf("what-if is not supported at the scope %q", scope)
+}
+
+// FormatWhatIfChanges formats the predicted changes in a human-readable way, the changes without any effect are skipped.
+func FormatWhatIfChanges(changes []WhatIfChange) string {
+	lines := make([]string, 0)
+	for _, change := range changes {
+		switch change.ChangeType {
+		case "NoChange", "Ignore":
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", change.ChangeType, change.ResourceId))
+		lines = append(lines, formatWhatIfPropertyChanges(change.Delta, "", "  ")...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatWhatIfPropertyChanges(changes []WhatIfPropertyChange, parentPath string, indent string) []string {
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	lines := make([]string, 0)
+	for _, change := range changes {
+		propertyPath := whatIfPropertyPath(parentPath, change.Path)
+		switch {
+		case len(change.Children) != 0:
+			lines = append(lines, formatWhatIfPropertyChanges(change.Children, propertyPath, indent)...)
+		case change.PropertyChangeType == "Create":
+			lines = append(lines, fmt.Sprintf("%s+ %s: %s", indent, propertyPath, formatWhatIfValue(change.After)))
+		case change.PropertyChangeType == "Delete":
+			lines = append(lines, fmt.Sprintf("%s- %s: %s", indent, propertyPath, formatWhatIfValue(change.Before)))
+		case change.PropertyChangeType == "Modify":
+			lines = append(lines, fmt.Sprintf("%s~ %s: %s => %s", indent, propertyPath, formatWhatIfValue(change.Before), formatWhatIfValue(change.After)))
+		}
+	}
+	return lines
+}
+
+// redactedValue is the value of the sensitive property in the predicted changes
+type redactedValue struct{}
+
+func formatWhatIfValue(input interface{}) string {
+	if _, ok := input.(redactedValue); ok {
+		return "(sensitive value)"
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Sprintf("%v", input)
+	}
+	return string(data)
+}
@@ -0,0 +1,215 @@
+package preflight
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func Test_WhatIfDeploymentScope(t *testing.T) {
+	testcases := []struct {
+		ParentId       string
+		ExpectedReg    string
+		ExpectedSchema string
+		ExpectedErr    bool
+	}{
+		{
+			ParentId:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg",
+			ExpectedReg:    "^/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Resources/deployments/azapi-what-if-.+$",
+			ExpectedSchema: "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+		},
+		{
+			ParentId:       "/subscriptions/00000000-0000-0000-0000-000000000000",
+			ExpectedReg:    "^/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Resources/deployments/azapi-what-if-.+$",
+			ExpectedSchema: "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#",
+		},
+		{
+			ParentId:       "/providers/Microsoft.Management/managementGroups/mg",
+			ExpectedReg:    "^/providers/Microsoft.Management/managementGroups/mg/providers/Microsoft.Resources/deployments/azapi-what-if-.+$",
+			ExpectedSchema: "https://schema.management.azure.com/schemas/2019-08-01/managementGroupDeploymentTemplate.json#",
+		},
+		{
+			ParentId:       "/",
+			ExpectedReg:    "^/providers/Microsoft.Resources/deployments/azapi-what-if-.+$",
+			ExpectedSchema: "https://schema.management.azure.com/schemas/2019-08-01/tenantDeploymentTemplate.json#",
+		},
+		{
+			ParentId:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet",
+			ExpectedErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		deploymentId, templateSchema, err := whatIfDeploymentScope(tc.ParentId)
+		if tc.ExpectedErr {
+			if err == nil {
+				t.Errorf("expected error, got nil for parent id %s", tc.ParentId)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected no error, got %v for parent id %s", err, tc.ParentId)
+			continue
+		}
+		if !regexp.MustCompile(tc.ExpectedReg).MatchString(deploymentId) {
+			t.Errorf("expected deployment id to match %s, got %s", tc.ExpectedReg, deploymentId)
+		}
+		if templateSchema != tc.ExpectedSchema {
+			t.Errorf("expected template schema %s, got %s", tc.ExpectedSchema, templateSchema)
+		}
+	}
+}
+
+func Test_FormatWhatIfChanges(t *testing.T) {
+	changes := []WhatIfChange{
+		{
+			ResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/unchanged",
+			ChangeType: "NoChange",
+		},
+		{
+			ResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet",
+			ChangeType: "Modify",
+			Delta: []WhatIfPropertyChange{
+				{
+					Path:               "tags.env",
+					PropertyChangeType: "Modify",
+					Before:             "dev",
+					After:              "prod",
+				},
+				{
+					Path:               "properties.addressSpace.addressPrefixes",
+					PropertyChangeType: "Array",
+					Children: []WhatIfPropertyChange{
+						{
+							Path:               "1",
+							PropertyChangeType: "Create",
+							After:              "10.1.0.0/16",
+						},
+					},
+				},
+				{
+					Path:               "properties.enableDdosProtection",
+					PropertyChangeType: "Delete",
+					Before:             false,
+				},
+			},
+		},
+	}
+
+	expected := `Modify /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet
+  + properties.addressSpace.addressPrefixes[1]: "10.1.0.0/16"
+  - properties.enableDdosProtection: false
+  ~ tags.env: "dev" => "prod"`
+	if actual := FormatWhatIfChanges(changes); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if actual := FormatWhatIfChanges(changes[:1]); actual != "" {
+		t.Errorf("expected no output for the changes without any effect, got %s", actual)
+	}
+}
+
+func Test_SkipWhatIfPropertyChanges(t *testing.T) {
+	resource := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"addressSpace": map[string]interface{}{
+				"addressPrefixes": []interface{}{"10.0.0.0/16", unknownPlaceholder},
+			},
+			"dhcpOptions": map[string]interface{}{
+				"dnsServers": unknownPlaceholder,
+			},
+			"enableDdosProtection": false,
+		},
+	}
+	skippedPaths := placeholderPaths(resource, "")
+	sort.Strings(skippedPaths)
+	expectedPaths := []string{"properties.addressSpace.addressPrefixes[1]", "properties.dhcpOptions.dnsServers"}
+	if !reflect.DeepEqual(skippedPaths, expectedPaths) {
+		t.Fatalf("expected placeholder paths %v, got %v", expectedPaths, skippedPaths)
+	}
+
+	changes := []WhatIfPropertyChange{
+		{
+			Path:               "properties.addressSpace.addressPrefixes",
+			PropertyChangeType: "Array",
+			Children: []WhatIfPropertyChange{
+				{
+					Path:               "1",
+					PropertyChangeType: "Modify",
+					Before:             "10.1.0.0/16",
+					After:              3,
+				},
+			},
+		},
+		{
+			Path:               "properties.dhcpOptions",
+			PropertyChangeType: "Modify",
+			Before:             map[string]interface{}{},
+			After:              map[string]interface{}{"dnsServers": 3},
+		},
+		{
+			Path:               "properties.enableDdosProtection",
+			PropertyChangeType: "Modify",
+			Before:             true,
+			After:              false,
+		},
+	}
+	expected := []WhatIfPropertyChange{changes[2]}
+	if actual := skipWhatIfPropertyChanges(changes, "", skippedPaths); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func Test_RedactWhatIfPropertyChanges(t *testing.T) {
+	sensitiveValue := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"administratorLoginPassword": "secret",
+			"keys":                       []interface{}{"key1"},
+		},
+	}
+	sensitivePaths := leafPaths(sensitiveValue, "", func(value interface{}) bool {
+		return value != unknownPlaceholder
+	})
+
+	changes := []WhatIfChange{
+		{
+			ResourceId: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Sql/servers/server",
+			ChangeType: "Modify",
+			Delta: []WhatIfPropertyChange{
+				{
+					Path:               "properties.administratorLoginPassword",
+					PropertyChangeType: "Create",
+					After:              "secret",
+				},
+				{
+					Path:               "properties.keys",
+					PropertyChangeType: "Array",
+					Children: []WhatIfPropertyChange{
+						{
+							Path:               "0",
+							PropertyChangeType: "Modify",
+							Before:             "key0",
+							After:              "key1",
+						},
+					},
+				},
+				{
+					Path:               "properties.version",
+					PropertyChangeType: "Modify",
+					Before:             "12.0",
+					After:              "14.0",
+				},
+			},
+		},
+	}
+	changes[0].Delta = redactWhatIfPropertyChanges(changes[0].Delta, "", sensitivePaths)
+
+	expected := `Modify /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Sql/servers/server
+  + properties.administratorLoginPassword: (sensitive value)
+  ~ properties.keys[0]: (sensitive value) => (sensitive value)
+  ~ properties.version: "12.0" => "14.0"`
+	if actual := FormatWhatIfChanges(changes); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/Azure/terraform-provider-azapi/internal/azure"
@@ -12,6 +13,8 @@ import (
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
 	"github.com/Azure/terraform-provider-azapi/utils"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -47,6 +50,62 @@ func schemaValidation(azureResourceType, apiVersion string, resourceDef *aztypes
 	return nil
 }
 
+func actionSchemaValidation(azureResourceType, apiVersion, actionName, method string, body interface{}) error {
+	// resource functions are only defined for POST requests with an action name
+	if actionName == "" || !strings.EqualFold(method, "POST") || body == nil {
+		return nil
+	}
+	log.Printf("[INFO] prepare validation for action: %s, resource type: %s, api-version: %s", actionName, azureResourceType, apiVersion)
+	functionDef, err := azure.GetResourceFunctionDefinition(azureResourceType, actionName, apiVersion)
+	if err != nil {
+		log.Printf("[INFO] skip validation for action %s: %+v", actionName, err)
+		return nil
+	}
+
+	errors := functionDef.Validate(utils.NormalizeObject(body), "")
+	if len(errors) != 0 {
+		errorMsg := "the argument \"body\" is invalid:\n"
+		for _, err := range errors {
+			errorMsg += fmt.Sprintf("%s\n", err.Error())
+		}
+		return schemaValidationError(errorMsg)
+	}
+	return nil
+}
+
+// bodyCompatibilityDiagnostics checks whether the body in state is compatible with the new api-version when the api-version in `type` is changed.
+// It reports the properties which are removed, whose types are changed or which become read-only in the new api-version.
+func bodyCompatibilityDiagnostics(stateType types.String, newType types.String, stateBody types.Dynamic) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if stateType.IsNull() || newType.IsUnknown() || stateType.Equal(newType) || !dynamic.IsFullyKnown(stateBody) {
+		return diags
+	}
+	oldResourceType, oldApiVersion, err := utils.GetAzureResourceTypeApiVersion(stateType.ValueString())
+	if err != nil {
+		return diags
+	}
+	newResourceType, newApiVersion, err := utils.GetAzureResourceTypeApiVersion(newType.ValueString())
+	if err != nil || !strings.EqualFold(oldResourceType, newResourceType) || oldApiVersion == newApiVersion {
+		return diags
+	}
+
+	oldDef, _ := azure.GetResourceDefinition(oldResourceType, oldApiVersion)
+	newDef, _ := azure.GetResourceDefinition(newResourceType, newApiVersion)
+	if oldDef == nil || newDef == nil {
+		return diags
+	}
+
+	var body interface{}
+	if err := unmarshalBody(stateBody, &body); err != nil {
+		return diags
+	}
+	for _, issue := range azure.CompareBodyCompatibility(oldDef, newDef, body) {
+		diags.AddAttributeWarning(path.Root("body"), "Incompatible property in the new api-version",
+			fmt.Sprintf("%s when the api-version is changed from %s to %s.", issue.String(), oldApiVersion, newApiVersion))
+	}
+	return diags
+}
+
 func schemaValidationError(detail string) error {
 	return fmt.Errorf("embedded schema validation failed: %s You can try to update `azapi` provider to "+
 		"the latest version or disable the validation using the feature flag `schema_validation_enabled = false` "+
@@ -177,6 +236,46 @@ func AsMapOfLists(input types.Map) map[string][]string {
 	return result
 }
 
+// isBodyChanged checks whether the planned body is different from the body in state, the changes at the paths in ignore_body_changes
+// and the changes in the order of the arrays in unordered_arrays are ignored.
+func isBodyChanged(planBody types.Dynamic, stateBody types.Dynamic, ignoreBodyChanges types.List, unorderedArrays types.List) bool {
+	if dynamic.SemanticallyEqual(planBody, stateBody) {
+		return false
+	}
+	ignorePaths, unorderedPaths := AsStringList(ignoreBodyChanges), AsStringList(unorderedArrays)
+	if len(ignorePaths)+len(unorderedPaths) == 0 || !dynamic.IsFullyKnown(planBody) {
+		return true
+	}
+	var planValue, stateValue interface{}
+	if err := unmarshalBody(planBody, &planValue); err != nil {
+		return true
+	}
+	if err := unmarshalBody(stateBody, &stateValue); err != nil {
+		return true
+	}
+	planValue = utils.KeepValuesAtPaths(planValue, stateValue, ignorePaths)
+	planValue = utils.OrderArraysAs(planValue, stateValue, unorderedPaths)
+	return !reflect.DeepEqual(planValue, stateValue)
+}
+
+// unmarshalBodyWithUnknownValues unmarshals the body which may contain unknown values, the unknown values are replaced with
+// the unknown value marker, so the known parts of the body can be validated by the embedded schema.
+func unmarshalBodyWithUnknownValues(input types.Dynamic, out interface{}) error {
+	if input.IsNull() || input.IsUnknown() || input.IsUnderlyingValueUnknown() {
+		return nil
+	}
+	data, err := dynamic.ToJSONWithUnknownValueHandler(input, func(value attr.Value) ([]byte, error) {
+		return json.Marshal(aztypes.UnknownValue)
+	})
+	if err != nil {
+		return fmt.Errorf(`invalid dynamic value: value: %s, err: %+v`, input.String(), err)
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return fmt.Errorf(`unmarshaling failed: value: %s, err: %+v`, string(data), err)
+	}
+	return nil
+}
+
 func unmarshalBody(input types.Dynamic, out interface{}) error {
 	if input.IsNull() || input.IsUnknown() || input.IsUnderlyingValueUnknown() {
 		return nil
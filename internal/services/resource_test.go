@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/Azure/terraform-provider-azapi/internal/services/dynamic"
+	"github.com/Azure/terraform-provider-azapi/internal/services/parse"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -129,3 +131,62 @@ func Test_FlattenOutputJMES(t *testing.T) {
 		}
 	}
 }
+
+func Test_FlattenDataPlaneBody(t *testing.T) {
+	testcases := []struct {
+		ResourceType string
+		ResponseJson string
+		ExpectedJson string
+	}{
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ResponseJson: `{"etag":"abc","key":"mykey","label":null,"content_type":"","value":"myvalue","tags":{},"locked":false,"last_modified":"2024-01-01T00:00:00+00:00"}`,
+			ExpectedJson: `{"content_type":"","value":"myvalue"}`,
+		},
+		{
+			ResourceType: "Microsoft.AppConfiguration/configurationStores/keyValues",
+			ResponseJson: `{"key":"mykey","value":"myvalue","tags":{"env":"test"},"locked":true}`,
+			ExpectedJson: `{"value":"myvalue","tags":{"env":"test"}}`,
+		},
+		{
+			ResourceType: "Microsoft.Purview/accounts/Scanning/classificationrules",
+			ResponseJson: `{"etag":"abc","kind":"Custom","properties":{"description":"rule"}}`,
+			ExpectedJson: `{"kind":"Custom","properties":{"description":"rule"}}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var responseBody interface{}
+		_ = json.Unmarshal([]byte(testcase.ResponseJson), &responseBody)
+		body, err := flattenDataPlaneBody(testcase.ResourceType, responseBody)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		data, err := dynamic.ToJSON(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		var actual, expected interface{}
+		_ = json.Unmarshal(data, &actual)
+		_ = json.Unmarshal([]byte(testcase.ExpectedJson), &expected)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %s, got %s", testcase.ExpectedJson, string(data))
+		}
+	}
+}
+
+func Test_BuildUpdateRequestBody(t *testing.T) {
+	var existing, requestBody, expected interface{}
+	_ = json.Unmarshal([]byte(`{"properties":{"sku":"Basic","rules":[{"name":"a"}],"tier":"Free"}}`), &existing)
+	_ = json.Unmarshal([]byte(`{"properties":{"sku":"Standard","rules":[{"name":"b"}]}}`), &requestBody)
+	_ = json.Unmarshal([]byte(`{"properties":{"sku":"Standard","rules":[{"name":"a"}],"tier":"Free"}}`), &expected)
+
+	model := AzapiUpdateResourceModel{
+		IgnoreBodyChanges: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("properties.rules")}),
+	}
+	actual := buildUpdateRequestBody(existing, requestBody, model, parse.ResourceId{})
+	if !reflect.DeepEqual(actual, expected) {
+		actualJson, _ := json.Marshal(actual)
+		t.Fatalf("expected the values at the paths in ignore_body_changes to be kept, got %s", string(actualJson))
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
 	jmes "github.com/jmespath/go-jmespath"
 )
 
@@ -64,6 +66,20 @@ func MergeObject(old interface{}, new interface{}) interface{} {
 type UpdateJsonOption struct {
 	IgnoreCasing          bool
 	IgnoreMissingProperty bool
+	// Schema is the type of the object, the case-only differences of the enum values defined in it are ignored
+	Schema *types.TypeBase
+}
+
+// forProperty returns the option used to update the property of the object
+func (o UpdateJsonOption) forProperty(key string, body map[string]interface{}) UpdateJsonOption {
+	o.Schema = propertyType(o.Schema, key, body)
+	return o
+}
+
+// forItem returns the option used to update the items of the array
+func (o UpdateJsonOption) forItem() UpdateJsonOption {
+	o.Schema = itemType(o.Schema)
+	return o
 }
 
 // UpdateObject is used to get an updated object which has same schema as old, but with new value
@@ -78,7 +94,7 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 			for key, value := range oldValue {
 				switch {
 				case newMap[key] != nil:
-					res[key] = UpdateObject(value, newMap[key], option)
+					res[key] = UpdateObject(value, newMap[key], option.forProperty(key, oldValue))
 				case option.IgnoreMissingProperty || isZeroValue(value):
 					res[key] = value
 				}
@@ -90,15 +106,17 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 			if len(oldValue) == 0 {
 				return new
 			}
+			itemOption := option.forItem()
 
-			hasIdentifier := identifierOfArrayItem(oldValue[0]) != ""
+			identifierKeys := ArrayItemIdentifierKeys(oldValue, newArr, itemOption.Schema)
+			hasIdentifier := ArrayItemIdentifier(oldValue[0], identifierKeys) != ""
 			if !hasIdentifier {
 				if len(oldValue) != len(newArr) {
 					return newArr
 				}
 				res := make([]interface{}, 0)
 				for index := range oldValue {
-					res = append(res, UpdateObject(oldValue[index], newArr[index], option))
+					res = append(res, UpdateObject(oldValue[index], newArr[index], itemOption))
 				}
 				return res
 			}
@@ -110,7 +128,7 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 				found := false
 				for index, newItem := range newArr {
 					if reflect.DeepEqual(oldItem, newItem) && !used[index] {
-						res = append(res, UpdateObject(oldItem, newItem, option))
+						res = append(res, UpdateObject(oldItem, newItem, itemOption))
 						used[index] = true
 						found = true
 						break
@@ -120,8 +138,8 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 					continue
 				}
 				for index, newItem := range newArr {
-					if areSameArrayItems(oldItem, newItem) && !used[index] {
-						res = append(res, UpdateObject(oldItem, newItem, option))
+					if areSameArrayItems(oldItem, newItem, identifierKeys) && !used[index] {
+						res = append(res, UpdateObject(oldItem, newItem, itemOption))
 						used[index] = true
 						break
 					}
@@ -137,7 +155,7 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 		}
 	case string:
 		if newStr, ok := new.(string); ok {
-			if option.IgnoreCasing && strings.EqualFold(oldValue, newStr) {
+			if strings.EqualFold(oldValue, newStr) && (option.IgnoreCasing || isEnumValue(option.Schema, oldValue)) {
 				return oldValue
 			}
 			if option.IgnoreMissingProperty && (regexp.MustCompile(`^\*+$`).MatchString(newStr) || "<redacted>" == newStr || "" == newStr) {
@@ -148,29 +166,216 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 	return new
 }
 
-func areSameArrayItems(a, b interface{}) bool {
-	aId := identifierOfArrayItem(a)
-	bId := identifierOfArrayItem(b)
+// propertyType returns the type of the property in the object type, the discriminated object type is resolved by the discriminator in the body
+func propertyType(t *types.TypeBase, key string, body map[string]interface{}) *types.TypeBase {
+	if t == nil {
+		return nil
+	}
+	switch v := (*t).(type) {
+	case *types.ResourceType:
+		if v != nil && v.Body != nil {
+			return propertyType(v.Body.Type, key, body)
+		}
+	case *types.ObjectType:
+		if v == nil {
+			return nil
+		}
+		if property, ok := v.Properties[key]; ok {
+			if property.Type != nil {
+				return property.Type.Type
+			}
+			return nil
+		}
+		if v.AdditionalProperties != nil {
+			return v.AdditionalProperties.Type
+		}
+	case *types.DiscriminatedObjectType:
+		if v == nil {
+			return nil
+		}
+		if property, ok := v.BaseProperties[key]; ok {
+			if property.Type != nil {
+				return property.Type.Type
+			}
+			return nil
+		}
+		if discriminator, ok := body[v.Discriminator].(string); ok && v.Elements[discriminator] != nil {
+			return propertyType(v.Elements[discriminator].Type, key, body)
+		}
+	case *types.UnionType:
+		if v == nil {
+			return nil
+		}
+		for _, element := range v.Elements {
+			if element == nil {
+				continue
+			}
+			if out := propertyType(element.Type, key, body); out != nil {
+				return out
+			}
+		}
+	}
+	return nil
+}
+
+// itemType returns the type of the items in the array type
+func itemType(t *types.TypeBase) *types.TypeBase {
+	if t == nil {
+		return nil
+	}
+	switch v := (*t).(type) {
+	case *types.ArrayType:
+		if v != nil && v.ItemType != nil {
+			return v.ItemType.Type
+		}
+	case *types.UnionType:
+		if v == nil {
+			return nil
+		}
+		for _, element := range v.Elements {
+			if element == nil {
+				continue
+			}
+			if out := itemType(element.Type); out != nil {
+				return out
+			}
+		}
+	}
+	return nil
+}
+
+// isEnumValue checks whether the value is one of the string literals defined in the type, the case differences are ignored
+func isEnumValue(t *types.TypeBase, value string) bool {
+	if t == nil {
+		return false
+	}
+	switch v := (*t).(type) {
+	case *types.StringLiteralType:
+		return v != nil && strings.EqualFold(v.Value, value)
+	case *types.UnionType:
+		if v == nil {
+			return false
+		}
+		for _, element := range v.Elements {
+			if element != nil && isEnumValue(element.Type, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func areSameArrayItems(a, b interface{}, identifierKeys []string) bool {
+	aId := ArrayItemIdentifier(a, identifierKeys)
+	bId := ArrayItemIdentifier(b, identifierKeys)
 	if aId == "" || bId == "" {
 		return false
 	}
 	return aId == bId
 }
 
-func identifierOfArrayItem(input interface{}) string {
+// ArrayItemIdentifierKeys returns the properties which identify the items of the two arrays, they're picked once for both arrays,
+// so that the items are compared by the same properties. The identifier properties defined in the schema are used if all the items
+// of both arrays have all of them, otherwise the items are identified by the `name`.
+func ArrayItemIdentifierKeys(a []interface{}, b []interface{}, schema *types.TypeBase) []string {
+	var keys []string
+	for _, item := range append(append(make([]interface{}, 0, len(a)+len(b)), a...), b...) {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return []string{"name"}
+		}
+		itemKeys := identifierProperties(schema, itemMap)
+		if len(itemKeys) == 0 || (keys != nil && !reflect.DeepEqual(keys, itemKeys)) {
+			return []string{"name"}
+		}
+		for _, key := range itemKeys {
+			if itemMap[key] == nil {
+				return []string{"name"}
+			}
+		}
+		keys = itemKeys
+	}
+	if len(keys) == 0 {
+		return []string{"name"}
+	}
+	return keys
+}
+
+// ArrayItemIdentifier returns the identifier of the array item which is composed of the values of the identifier keys,
+// it returns an empty string if the item doesn't have all of them. The `name` is used as the identifier if it's the only key.
+func ArrayItemIdentifier(input interface{}, identifierKeys []string) string {
 	inputMap, ok := input.(map[string]interface{})
-	if !ok {
+	if !ok || len(identifierKeys) == 0 {
 		return ""
 	}
-	name := inputMap["name"]
-	if name == nil {
-		return ""
+	if len(identifierKeys) == 1 && identifierKeys[0] == "name" {
+		nameValue, ok := inputMap["name"].(string)
+		if !ok {
+			return ""
+		}
+		return nameValue
+	}
+	values := make([]interface{}, 0, len(identifierKeys))
+	for _, key := range identifierKeys {
+		if inputMap[key] == nil {
+			return ""
+		}
+		values = append(values, inputMap[key])
 	}
-	nameValue, ok := name.(string)
-	if !ok {
+	data, err := json.Marshal(values)
+	if err != nil {
 		return ""
 	}
-	return nameValue
+	return string(data)
+}
+
+// identifierProperties returns the sorted names of the properties which are flagged as identifiers in the object type,
+// the discriminated object type is resolved by the discriminator in the body.
+func identifierProperties(t *types.TypeBase, body map[string]interface{}) []string {
+	if t == nil {
+		return nil
+	}
+	properties := make(map[string]types.ObjectProperty)
+	switch v := (*t).(type) {
+	case *types.ObjectType:
+		if v == nil {
+			return nil
+		}
+		properties = v.Properties
+	case *types.DiscriminatedObjectType:
+		if v == nil {
+			return nil
+		}
+		for key, value := range v.BaseProperties {
+			properties[key] = value
+		}
+		if discriminator, ok := body[v.Discriminator].(string); ok && v.Elements[discriminator] != nil {
+			for _, key := range identifierProperties(v.Elements[discriminator].Type, body) {
+				properties[key] = types.ObjectProperty{Flags: []types.ObjectPropertyFlag{types.Identifier}}
+			}
+		}
+	case *types.UnionType:
+		if v == nil {
+			return nil
+		}
+		for _, element := range v.Elements {
+			if element == nil {
+				continue
+			}
+			if out := identifierProperties(element.Type, body); len(out) != 0 {
+				return out
+			}
+		}
+	}
+
+	keys := make([]string, 0)
+	for key, property := range properties {
+		if property.IsIdentifier() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // ExtractObject is used to extract object from old for a json path
@@ -256,6 +461,181 @@ func OverrideWithPaths(old interface{}, new interface{}, path string, pathSet ma
 	return old, nil
 }
 
+// ParseBodyPath parses the path in the body, e.g. `properties.rules[0].action`, to the keys and array indexes, e.g. [properties, rules, [0], action].
+// The array index `[*]` matches all the items in the array.
+func ParseBodyPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the path is empty")
+	}
+	segments := make([]string, 0)
+	for _, part := range strings.Split(path, ".") {
+		matches := bodyPathPartRegex.FindStringSubmatch(part)
+		if matches == nil || (matches[1] == "" && matches[2] == "") {
+			return nil, fmt.Errorf("the path %q is invalid, it should be a dotted path with optional array indexes, e.g. `properties.rules[0].action`", path)
+		}
+		if matches[1] != "" {
+			segments = append(segments, matches[1])
+		}
+		for _, index := range bodyPathIndexRegex.FindAllString(matches[2], -1) {
+			segments = append(segments, index)
+		}
+	}
+	return segments, nil
+}
+
+var bodyPathPartRegex = regexp.MustCompile(`^([^.\[\]]*)((?:\[(?:\d+|\*)\])*)$`)
+
+var bodyPathIndexRegex = regexp.MustCompile(`\[(?:\d+|\*)\]`)
+
+// KeepValuesAtPaths returns a copy of the input whose values at the paths are replaced with the values at the same paths in the source,
+// the values are removed if they don't exist in the source. The invalid paths are skipped.
+func KeepValuesAtPaths(input interface{}, source interface{}, paths []string) interface{} {
+	for _, path := range paths {
+		segments, err := ParseBodyPath(path)
+		if err != nil {
+			continue
+		}
+		input, _ = keepValueAtPath(input, input != nil, source, source != nil, segments)
+	}
+	return input
+}
+
+func keepValueAtPath(input interface{}, inputExists bool, source interface{}, sourceExists bool, segments []string) (interface{}, bool) {
+	if len(segments) == 0 {
+		return source, sourceExists
+	}
+	segment := segments[0]
+
+	if strings.HasPrefix(segment, "[") {
+		inputArr, ok := input.([]interface{})
+		if !ok {
+			return input, inputExists
+		}
+		sourceArr, _ := source.([]interface{})
+		out := make([]interface{}, len(inputArr))
+		copy(out, inputArr)
+		for index := range out {
+			if segment != "[*]" && segment != fmt.Sprintf("[%d]", index) {
+				continue
+			}
+			// the items which don't exist in the source are kept, and the array items can't be removed, otherwise the indexes of the other items are changed
+			if index >= len(sourceArr) {
+				continue
+			}
+			if value, ok := keepValueAtPath(out[index], true, sourceArr[index], true, segments[1:]); ok {
+				out[index] = value
+			}
+		}
+		return out, inputExists
+	}
+
+	inputMap, ok := input.(map[string]interface{})
+	if !ok && input != nil {
+		return input, inputExists
+	}
+	sourceMap, _ := source.(map[string]interface{})
+	inputValue, inputHas := inputMap[segment]
+	sourceValue, sourceHas := sourceMap[segment]
+	value, has := keepValueAtPath(inputValue, inputHas, sourceValue, sourceHas, segments[1:])
+
+	out := make(map[string]interface{})
+	for key, v := range inputMap {
+		out[key] = v
+	}
+	if has {
+		out[segment] = value
+	} else {
+		delete(out, segment)
+	}
+	if len(out) == 0 && !inputExists {
+		return nil, false
+	}
+	return out, true
+}
+
+// OrderArraysAs returns a copy of the input whose arrays at the paths are in the same order as the arrays at the same paths in the reference,
+// if they contain the same items regardless of the order. The invalid paths are skipped.
+func OrderArraysAs(input interface{}, reference interface{}, paths []string) interface{} {
+	for _, path := range paths {
+		segments, err := ParseBodyPath(path)
+		if err != nil {
+			continue
+		}
+		input = orderArrayAtPath(input, reference, segments)
+	}
+	return input
+}
+
+func orderArrayAtPath(input interface{}, reference interface{}, segments []string) interface{} {
+	if len(segments) == 0 {
+		inputArr, ok := input.([]interface{})
+		if !ok {
+			return input
+		}
+		referenceArr, ok := reference.([]interface{})
+		if !ok || !areSameItemsIgnoringOrder(inputArr, referenceArr) {
+			return input
+		}
+		out := make([]interface{}, len(referenceArr))
+		copy(out, referenceArr)
+		return out
+	}
+	segment := segments[0]
+
+	if strings.HasPrefix(segment, "[") {
+		inputArr, ok := input.([]interface{})
+		if !ok {
+			return input
+		}
+		referenceArr, _ := reference.([]interface{})
+		out := make([]interface{}, len(inputArr))
+		copy(out, inputArr)
+		for index := range out {
+			if segment != "[*]" && segment != fmt.Sprintf("[%d]", index) {
+				continue
+			}
+			if index < len(referenceArr) {
+				out[index] = orderArrayAtPath(out[index], referenceArr[index], segments[1:])
+			}
+		}
+		return out
+	}
+
+	inputMap, ok := input.(map[string]interface{})
+	if !ok || inputMap[segment] == nil {
+		return input
+	}
+	referenceMap, _ := reference.(map[string]interface{})
+	out := make(map[string]interface{})
+	for key, value := range inputMap {
+		out[key] = value
+	}
+	out[segment] = orderArrayAtPath(inputMap[segment], referenceMap[segment], segments[1:])
+	return out
+}
+
+// areSameItemsIgnoringOrder checks whether the arrays contain the same items, and each item appears the same number of times in them
+func areSameItemsIgnoringOrder(a []interface{}, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	used := make([]bool, len(b))
+	for _, aItem := range a {
+		found := false
+		for index, bItem := range b {
+			if !used[index] && reflect.DeepEqual(aItem, bItem) {
+				used[index] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // mergeArray is used to merge two array, if overlaps, use old value. `name` is used as key to compare
 func mergeArray(old []interface{}, new []interface{}) []interface{} {
 	oldMap := make(map[string]interface{})
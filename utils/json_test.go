@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Azure/terraform-provider-azapi/internal/azure/types"
 	"github.com/Azure/terraform-provider-azapi/utils"
 )
 
@@ -423,6 +424,157 @@ func Test_UpdateObject(t *testing.T) {
 	}
 }
 
+func Test_UpdateObjectWithSchema(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	skuNameType := &types.TypeReference{Type: (&types.UnionType{Elements: []*types.TypeReference{
+		{Type: (&types.StringLiteralType{Value: "Standard_LRS"}).AsTypeBase()},
+		{Type: (&types.StringLiteralType{Value: "Premium_LRS"}).AsTypeBase()},
+		{Type: (&types.StringType{}).AsTypeBase()},
+	}}).AsTypeBase()}
+	schema := (&types.ResourceType{
+		Body: &types.TypeReference{Type: (&types.ObjectType{
+			Properties: map[string]types.ObjectProperty{
+				"sku": {Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"name": {Type: skuNameType},
+					},
+				}).AsTypeBase()}},
+				"properties": {Type: &types.TypeReference{Type: (&types.ObjectType{
+					Properties: map[string]types.ObjectProperty{
+						"subnetId": {Type: stringType},
+						"rules": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+							Properties: map[string]types.ObjectProperty{
+								"action": {Type: &types.TypeReference{Type: (&types.StringLiteralType{Value: "Allow"}).AsTypeBase()}},
+							},
+						}).AsTypeBase()}}).AsTypeBase()}},
+					},
+				}).AsTypeBase()}},
+			},
+		}).AsTypeBase()},
+	}).AsTypeBase()
+
+	old := map[string]interface{}{
+		"sku": map[string]interface{}{
+			"name": "Standard_LRS",
+		},
+		"properties": map[string]interface{}{
+			"subnetId": "/subscriptions/000/resourceGroups/myRG",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"action": "Allow",
+				},
+			},
+		},
+	}
+	new := map[string]interface{}{
+		"sku": map[string]interface{}{
+			"name": "standard_lrs",
+		},
+		"properties": map[string]interface{}{
+			"subnetId": "/subscriptions/000/resourcegroups/myrg",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"action": "allow",
+				},
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"sku": map[string]interface{}{
+			"name": "Standard_LRS",
+		},
+		"properties": map[string]interface{}{
+			"subnetId": "/subscriptions/000/resourcegroups/myrg",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"action": "Allow",
+				},
+			},
+		},
+	}
+
+	result := utils.UpdateObject(old, new, utils.UpdateJsonOption{Schema: schema})
+	if !reflect.DeepEqual(result, expected) {
+		expectedJson, _ := json.Marshal(expected)
+		resultJson, _ := json.Marshal(result)
+		t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+	}
+}
+
+func Test_UpdateObjectWithIdentifier(t *testing.T) {
+	stringType := &types.TypeReference{Type: (&types.StringType{}).AsTypeBase()}
+	integerType := &types.TypeReference{Type: (&types.IntegerType{}).AsTypeBase()}
+	ruleType := &types.TypeReference{Type: (&types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"priority": {Type: integerType, Flags: []types.ObjectPropertyFlag{types.Required, types.Identifier}},
+			"action":   {Type: stringType},
+		},
+	}).AsTypeBase()}
+	schema := (&types.ObjectType{
+		Properties: map[string]types.ObjectProperty{
+			"rules": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: ruleType}).AsTypeBase()}},
+			"items": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"name":  {Type: stringType},
+					"value": {Type: stringType},
+				},
+			}).AsTypeBase()}}).AsTypeBase()}},
+			"resources": {Type: &types.TypeReference{Type: (&types.ArrayType{ItemType: &types.TypeReference{Type: (&types.ObjectType{
+				Properties: map[string]types.ObjectProperty{
+					"id":    {Type: stringType, Flags: []types.ObjectPropertyFlag{types.ReadOnly, types.Identifier}},
+					"name":  {Type: stringType},
+					"value": {Type: stringType},
+				},
+			}).AsTypeBase()}}).AsTypeBase()}},
+		},
+	}).AsTypeBase()
+
+	testcases := []struct {
+		OldJson    string
+		NewJson    string
+		ExpectJson string
+	}{
+		{
+			// the items are matched by the identifier properties
+			OldJson:    `{"rules":[{"priority":100,"action":"Allow"},{"priority":200,"action":"Deny"}]}`,
+			NewJson:    `{"rules":[{"priority":200,"action":"Deny"},{"priority":100,"action":"Deny"}]}`,
+			ExpectJson: `{"rules":[{"priority":100,"action":"Deny"},{"priority":200,"action":"Deny"}]}`,
+		},
+		{
+			// the items are matched by the name if there's no identifier property
+			OldJson:    `{"items":[{"name":"a","value":"1"},{"name":"b","value":"2"}]}`,
+			NewJson:    `{"items":[{"name":"b","value":"2"},{"name":"a","value":"3"}]}`,
+			ExpectJson: `{"items":[{"name":"a","value":"3"},{"name":"b","value":"2"}]}`,
+		},
+		{
+			// the items are matched by the name if the identifier properties are missing from one side
+			OldJson:    `{"resources":[{"name":"a","value":"1"},{"name":"b","value":"2"}]}`,
+			NewJson:    `{"resources":[{"id":"/resources/b","name":"b","value":"2"},{"id":"/resources/a","name":"a","value":"3"}]}`,
+			ExpectJson: `{"resources":[{"name":"a","value":"3"},{"name":"b","value":"2"}]}`,
+		},
+		{
+			// the items are matched by the position if they don't have the identifier properties or the name
+			OldJson:    `{"rules":[{"action":"Allow"},{"action":"Deny"}]}`,
+			NewJson:    `{"rules":[{"action":"Deny"},{"action":"Allow"}]}`,
+			ExpectJson: `{"rules":[{"action":"Deny"},{"action":"Allow"}]}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var new, old, expected interface{}
+		_ = json.Unmarshal([]byte(testcase.OldJson), &old)
+		_ = json.Unmarshal([]byte(testcase.NewJson), &new)
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+
+		result := utils.UpdateObject(old, new, utils.UpdateJsonOption{Schema: schema})
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}
+
 func Test_MergeObject(t *testing.T) {
 	oldJson := `
  {
@@ -1101,3 +1253,127 @@ func Test_RemoveFields(t *testing.T) {
 		}
 	}
 }
+
+func Test_ParseBodyPath(t *testing.T) {
+	testcases := []struct {
+		Path     string
+		Expected []string
+		Error    bool
+	}{
+		{Path: "properties.networkAcls", Expected: []string{"properties", "networkAcls"}},
+		{Path: "properties.rules[0].action", Expected: []string{"properties", "rules", "[0]", "action"}},
+		{Path: "properties.rules[*].ips[1]", Expected: []string{"properties", "rules", "[*]", "ips", "[1]"}},
+		{Path: "", Error: true},
+		{Path: "properties..rules", Error: true},
+		{Path: "properties.rules[?action=='Allow']", Error: true},
+	}
+
+	for _, testcase := range testcases {
+		actual, err := utils.ParseBodyPath(testcase.Path)
+		if (err != nil) != testcase.Error {
+			t.Fatalf("Expected error %v but got %v for path %q", testcase.Error, err, testcase.Path)
+		}
+		if !testcase.Error && !reflect.DeepEqual(actual, testcase.Expected) {
+			t.Fatalf("Expected %v but got %v for path %q", testcase.Expected, actual, testcase.Path)
+		}
+	}
+}
+
+func Test_KeepValuesAtPaths(t *testing.T) {
+	testcases := []struct {
+		InputJson  string
+		SourceJson string
+		Paths      []string
+		ExpectJson string
+	}{
+		{
+			InputJson:  `{"properties":{"sku":"Basic","networkAcls":{"defaultAction":"Allow"}}}`,
+			SourceJson: `{"properties":{"sku":"Standard","networkAcls":{"defaultAction":"Deny","ipRules":[{"value":"1.1.1.1"}]}}}`,
+			Paths:      []string{"properties.networkAcls"},
+			ExpectJson: `{"properties":{"sku":"Basic","networkAcls":{"defaultAction":"Deny","ipRules":[{"value":"1.1.1.1"}]}}}`,
+		},
+		{
+			// the value is removed if it doesn't exist in the source
+			InputJson:  `{"properties":{"sku":"Basic","publicNetworkAccess":"Enabled"}}`,
+			SourceJson: `{"properties":{"sku":"Standard"}}`,
+			Paths:      []string{"properties.publicNetworkAccess"},
+			ExpectJson: `{"properties":{"sku":"Basic"}}`,
+		},
+		{
+			// the parent objects are created if the value exists in the source
+			InputJson:  `{"location":"westus"}`,
+			SourceJson: `{"properties":{"logs":{"enabled":true}}}`,
+			Paths:      []string{"properties.logs.enabled"},
+			ExpectJson: `{"location":"westus","properties":{"logs":{"enabled":true}}}`,
+		},
+		{
+			InputJson:  `{"rules":[{"name":"a","action":"Allow"},{"name":"b","action":"Allow"}]}`,
+			SourceJson: `{"rules":[{"name":"a","action":"Deny"},{"name":"b","action":"Deny"}]}`,
+			Paths:      []string{"rules[1].action"},
+			ExpectJson: `{"rules":[{"name":"a","action":"Allow"},{"name":"b","action":"Deny"}]}`,
+		},
+		{
+			InputJson:  `{"rules":[{"name":"a","action":"Allow"},{"name":"b","action":"Allow"}]}`,
+			SourceJson: `{"rules":[{"name":"a","action":"Deny"}]}`,
+			Paths:      []string{"rules[*].action", "invalid..path"},
+			ExpectJson: `{"rules":[{"name":"a","action":"Deny"},{"name":"b","action":"Allow"}]}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var input, source, expected interface{}
+		_ = json.Unmarshal([]byte(testcase.InputJson), &input)
+		_ = json.Unmarshal([]byte(testcase.SourceJson), &source)
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+
+		result := utils.KeepValuesAtPaths(input, source, testcase.Paths)
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}
+
+func Test_OrderArraysAs(t *testing.T) {
+	testcases := []struct {
+		InputJson     string
+		ReferenceJson string
+		Paths         []string
+		ExpectJson    string
+	}{
+		{
+			InputJson:     `{"properties":{"allowedOrigins":["b","a","c"],"dnsServers":["2","1"]}}`,
+			ReferenceJson: `{"properties":{"allowedOrigins":["a","b","c"],"dnsServers":["1","2"]}}`,
+			Paths:         []string{"properties.allowedOrigins"},
+			ExpectJson:    `{"properties":{"allowedOrigins":["a","b","c"],"dnsServers":["2","1"]}}`,
+		},
+		{
+			// the order is kept if the items are different
+			InputJson:     `{"ipRules":[{"value":"2.2.2.2"},{"value":"1.1.1.1"},{"value":"1.1.1.1"}]}`,
+			ReferenceJson: `{"ipRules":[{"value":"1.1.1.1"},{"value":"2.2.2.2"},{"value":"2.2.2.2"}]}`,
+			Paths:         []string{"ipRules"},
+			ExpectJson:    `{"ipRules":[{"value":"2.2.2.2"},{"value":"1.1.1.1"},{"value":"1.1.1.1"}]}`,
+		},
+		{
+			InputJson:     `{"rules":[{"name":"a","ips":["2","1"]},{"name":"b","ips":["4","3"]}]}`,
+			ReferenceJson: `{"rules":[{"name":"a","ips":["1","2"]},{"name":"b","ips":["3","4"]}]}`,
+			Paths:         []string{"rules[*].ips", "invalid..path"},
+			ExpectJson:    `{"rules":[{"name":"a","ips":["1","2"]},{"name":"b","ips":["3","4"]}]}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var input, reference, expected interface{}
+		_ = json.Unmarshal([]byte(testcase.InputJson), &input)
+		_ = json.Unmarshal([]byte(testcase.ReferenceJson), &reference)
+		_ = json.Unmarshal([]byte(testcase.ExpectJson), &expected)
+
+		result := utils.OrderArraysAs(input, reference, testcase.Paths)
+		if !reflect.DeepEqual(result, expected) {
+			expectedJson, _ := json.Marshal(expected)
+			resultJson, _ := json.Marshal(result)
+			t.Fatalf("Expected %s but got %s", expectedJson, resultJson)
+		}
+	}
+}